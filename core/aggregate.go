@@ -0,0 +1,195 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrEmptyAggregation is returned when there is nothing to aggregate
+	ErrEmptyAggregation = errors.New("nothing to aggregate")
+	// ErrAggregationLength is returned when numbers of signatures and public keys differ
+	ErrAggregationLength = errors.New("numbers of signatures and public keys differ")
+	// ErrEmptyAggregationValue is returned when one of aggregated signatures or public keys is empty
+	ErrEmptyAggregationValue = errors.New("empty signature or public key in aggregation")
+	// ErrIdentityAggregationValue is returned by strict aggregation when one of aggregated signatures
+	// or public keys is the point at infinity
+	ErrIdentityAggregationValue = errors.New("identity signature or public key in aggregation")
+	// ErrDuplicateAggregationValue is returned by strict aggregation when a signature or a public key
+	// is aggregated more than once
+	ErrDuplicateAggregationValue = errors.New("duplicate signature or public key in aggregation")
+	// ErrBitmapLength is returned when the bitmap of a subset does not match the number of members
+	ErrBitmapLength = errors.New("bitmap does not match the number of members")
+)
+
+// AggregateStrict adds the given signatures like Aggregate, but returns an error if any of them is
+// empty or the identity or both are the same
+func (s *Signature) AggregateStrict(next *Signature) (*Signature, error) {
+	return AggregateSignaturesStrict([]*Signature{s, next})
+}
+
+// AggregateStrict aggregates the given keys like Aggregate, but returns an error if any of them is
+// empty or the identity or both are the same
+func (p *PublicKey) AggregateStrict(next *PublicKey) (*PublicKey, error) {
+	return AggregatePublicKeysStrict([]*PublicKey{p, next})
+}
+
+// AggregateSignaturesStrict sums the given signatures like AggregateSignatures, but returns an error for
+// empty input, empty or identity signatures and signatures with the same serialized point
+func AggregateSignaturesStrict(signatures []*Signature) (*Signature, error) {
+	if len(signatures) == 0 {
+		return nil, ErrEmptyAggregation
+	}
+
+	seen := make(map[string]int, len(signatures))
+	res := new(G1)
+
+	for i, signature := range signatures {
+		if signature == nil || signature.p == nil {
+			return nil, fmt.Errorf("%w: signature %d", ErrEmptyAggregationValue, i)
+		}
+
+		if signature.p.IsZero() {
+			return nil, fmt.Errorf("%w: signature %d", ErrIdentityAggregationValue, i)
+		}
+
+		key := string(G1ToBytes(signature.p))
+		if j, ok := seen[key]; ok {
+			return nil, fmt.Errorf("%w: signatures %d and %d", ErrDuplicateAggregationValue, j, i)
+		}
+
+		seen[key] = i
+
+		G1Add(res, res, signature.p)
+	}
+
+	return newSignature(res), nil
+}
+
+// AggregatePublicKeysStrict calculates P1 + P2 + ... like AggregatePublicKeys, but returns an error for
+// empty input, empty or identity keys and keys with the same serialized point
+func AggregatePublicKeysStrict(pubs []*PublicKey) (*PublicKey, error) {
+	if len(pubs) == 0 {
+		return nil, ErrEmptyAggregation
+	}
+
+	seen := make(map[string]int, len(pubs))
+	res := new(G2)
+
+	for i, pub := range pubs {
+		if pub == nil || pub.p == nil {
+			return nil, fmt.Errorf("%w: public key %d", ErrEmptyAggregationValue, i)
+		}
+
+		if pub.p.IsZero() {
+			return nil, fmt.Errorf("%w: public key %d", ErrIdentityAggregationValue, i)
+		}
+
+		key := string(G2ToBytes(pub.p))
+		if j, ok := seen[key]; ok {
+			return nil, fmt.Errorf("%w: public keys %d and %d", ErrDuplicateAggregationValue, j, i)
+		}
+
+		seen[key] = i
+
+		G2Add(res, res, pub.p)
+	}
+
+	return newPublicKey(res), nil
+}
+
+// AggregateSignaturesSubset derives the aggregate of signatures[i] with bit i of the bitmap set, i.e.
+// bitmap[i/8]>>(i%8)&1 == 1, from full which is the aggregate of all signatures. If most members are
+// included, the excluded ones are subtracted from full, otherwise the included ones are summed.
+// Either way the result is the same as AggregateSignatures of the subset
+func AggregateSignaturesSubset(full *Signature, signatures []*Signature, bitmap []byte) (*Signature, error) {
+	if full.p == nil {
+		return nil, fmt.Errorf("%w: full aggregate", ErrEmptyAggregationValue)
+	}
+
+	included, err := bitmapCount(bitmap, len(signatures))
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(G1)
+	subtract := 2*included >= len(signatures)
+
+	if subtract {
+		*res = *full.p
+	}
+
+	for i, signature := range signatures {
+		if signature.p == nil || bitmapHas(bitmap, i) == subtract {
+			continue
+		}
+
+		if subtract {
+			G1Sub(res, res, signature.p)
+		} else {
+			G1Add(res, res, signature.p)
+		}
+	}
+
+	return newSignature(res), nil
+}
+
+// AggregatePublicKeysSubset derives the aggregate of pubs[i] with bit i of the bitmap set from full
+// which is the aggregate of all keys, see AggregateSignaturesSubset
+func AggregatePublicKeysSubset(full *PublicKey, pubs []*PublicKey, bitmap []byte) (*PublicKey, error) {
+	if full.p == nil {
+		return nil, fmt.Errorf("%w: full aggregate", ErrEmptyAggregationValue)
+	}
+
+	included, err := bitmapCount(bitmap, len(pubs))
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(G2)
+	subtract := 2*included >= len(pubs)
+
+	if subtract {
+		*res = *full.p
+	}
+
+	for i, pub := range pubs {
+		if pub.p == nil || bitmapHas(bitmap, i) == subtract {
+			continue
+		}
+
+		if subtract {
+			G2Sub(res, res, pub.p)
+		} else {
+			G2Add(res, res, pub.p)
+		}
+	}
+
+	return newPublicKey(res), nil
+}
+
+// bitmapCount returns the number of set bits of the bitmap of n members.
+// The bitmap must have (n + 7) / 8 bytes and no bits set after the member n - 1
+func bitmapCount(bitmap []byte, n int) (int, error) {
+	if len(bitmap) != (n+7)/8 {
+		return 0, fmt.Errorf("%w: %d bytes for %d members", ErrBitmapLength, len(bitmap), n)
+	}
+
+	if n%8 != 0 && bitmap[len(bitmap)-1]>>(n%8) != 0 {
+		return 0, fmt.Errorf("%w: bits set after member %d", ErrBitmapLength, n-1)
+	}
+
+	count := 0
+
+	for i := 0; i < n; i++ {
+		if bitmapHas(bitmap, i) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func bitmapHas(bitmap []byte, i int) bool {
+	return bitmap[i/8]>>(i%8)&1 == 1
+}
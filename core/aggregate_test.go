@@ -0,0 +1,164 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AggregateStrict(t *testing.T) {
+	t.Parallel()
+
+	validTestMsg := testGenRandomBytes(t, messageSize)
+
+	blsKeys, err := CreateRandomBlsKeys(3)
+	require.NoError(t, err)
+
+	publicKeys := CollectPublicKeys(blsKeys)
+	signatures := make([]*Signature, len(blsKeys))
+
+	for i, key := range blsKeys {
+		signatures[i], err = key.Sign(validTestMsg)
+		require.NoError(t, err)
+	}
+
+	signature, err := AggregateSignaturesStrict(signatures)
+	require.NoError(t, err)
+	assert.True(t, AggregateSignatures(signatures).p.IsEqual(signature.p))
+
+	publicKey, err := AggregatePublicKeysStrict(publicKeys)
+	require.NoError(t, err)
+	assert.True(t, AggregatePublicKeys(publicKeys).p.IsEqual(publicKey.p))
+
+	assert.True(t, signature.Verify(publicKey, validTestMsg))
+
+	signature, err = signatures[0].AggregateStrict(signatures[1])
+	require.NoError(t, err)
+	assert.True(t, signatures[0].Aggregate(signatures[1]).p.IsEqual(signature.p))
+
+	publicKey, err = publicKeys[0].AggregateStrict(publicKeys[1])
+	require.NoError(t, err)
+	assert.True(t, publicKeys[0].Aggregate(publicKeys[1]).p.IsEqual(publicKey.p))
+
+	// a copy of the key is detected by the serialized point
+	duplicate, err := UnmarshalPublicKey(publicKeys[1].Marshal())
+	require.NoError(t, err)
+
+	identitySignature, identityPublicKey := newSignature(new(G1)), newPublicKey(new(G2))
+
+	for _, c := range []struct {
+		signatures []*Signature
+		publicKeys []*PublicKey
+		err        error
+	}{
+		{nil, nil, ErrEmptyAggregation},
+		{[]*Signature{signatures[0], nil}, []*PublicKey{publicKeys[0], nil}, ErrEmptyAggregationValue},
+		{[]*Signature{{}, signatures[0]}, []*PublicKey{{}, publicKeys[0]}, ErrEmptyAggregationValue},
+		{[]*Signature{signatures[0], identitySignature}, []*PublicKey{publicKeys[0], identityPublicKey}, ErrIdentityAggregationValue},
+		{[]*Signature{signatures[0], signatures[1], signatures[0]}, []*PublicKey{publicKeys[0], publicKeys[1], duplicate}, ErrDuplicateAggregationValue},
+	} {
+		_, err := AggregateSignaturesStrict(c.signatures)
+		assert.ErrorIs(t, err, c.err)
+
+		_, err = AggregatePublicKeysStrict(c.publicKeys)
+		assert.ErrorIs(t, err, c.err)
+	}
+
+	_, err = AggregatePublicKeysStrict([]*PublicKey{publicKeys[0], publicKeys[1], duplicate})
+	assert.EqualError(t, err, "duplicate signature or public key in aggregation: public keys 1 and 2")
+
+	_, err = signatures[2].AggregateStrict(signatures[2])
+	assert.ErrorIs(t, err, ErrDuplicateAggregationValue)
+
+	_, err = publicKeys[2].AggregateStrict(&PublicKey{})
+	assert.ErrorIs(t, err, ErrEmptyAggregationValue)
+}
+
+func Test_Remove(t *testing.T) {
+	t.Parallel()
+
+	validTestMsg := testGenRandomBytes(t, messageSize)
+
+	blsKeys, err := CreateRandomBlsKeys(3)
+	require.NoError(t, err)
+
+	publicKeys := CollectPublicKeys(blsKeys)
+	signatures := make([]*Signature, len(blsKeys))
+
+	for i, key := range blsKeys {
+		signatures[i], err = key.Sign(validTestMsg)
+		require.NoError(t, err)
+	}
+
+	signature := AggregateSignatures(signatures).Remove(signatures[1])
+	publicKey := AggregatePublicKeys(publicKeys).Remove(publicKeys[1])
+
+	assert.True(t, signatures[0].Aggregate(signatures[2]).p.IsEqual(signature.p))
+	assert.True(t, publicKeys[0].Aggregate(publicKeys[2]).p.IsEqual(publicKey.p))
+	assert.True(t, signature.Verify(publicKey, validTestMsg))
+
+	// empty values are the identity
+	assert.True(t, signatures[0].Remove(&Signature{}).p.IsEqual(signatures[0].p))
+	assert.True(t, publicKeys[0].Remove(&PublicKey{}).p.IsEqual(publicKeys[0].p))
+	assert.True(t, (&Signature{}).Remove(signatures[0]).Aggregate(signatures[0]).p.IsZero())
+	assert.True(t, (&PublicKey{}).Remove(publicKeys[0]).Aggregate(publicKeys[0]).p.IsZero())
+	assert.True(t, signatures[0].Remove(signatures[0]).p.IsZero())
+}
+
+func Test_AggregateSubset(t *testing.T) {
+	t.Parallel()
+
+	const count = 11
+
+	var x Fr
+
+	publicKeys, signatures := make([]*PublicKey, count), make([]*Signature, count)
+
+	for i := 0; i < count; i++ {
+		require.True(t, x.SetByCSPRNG())
+
+		p, q := new(G1), new(G2)
+
+		G1MulGenerator(p, &x)
+		G2MulGenerator(q, &x)
+
+		signatures[i], publicKeys[i] = newSignature(p), newPublicKey(q)
+	}
+
+	fullSignature, fullPublicKey := AggregateSignatures(signatures), AggregatePublicKeys(publicKeys)
+
+	// most members excluded, most members included, all and none of them
+	for _, bitmap := range [][]byte{{0x05, 0x02}, {0xfb, 0x06}, {0xff, 0x07}, {0x00, 0x00}} {
+		var (
+			subsetSignatures []*Signature
+			subsetPublicKeys []*PublicKey
+		)
+
+		for i := 0; i < count; i++ {
+			if bitmap[i/8]>>(i%8)&1 == 1 {
+				subsetSignatures = append(subsetSignatures, signatures[i])
+				subsetPublicKeys = append(subsetPublicKeys, publicKeys[i])
+			}
+		}
+
+		signature, err := AggregateSignaturesSubset(fullSignature, signatures, bitmap)
+		require.NoError(t, err)
+		assert.True(t, AggregateSignatures(subsetSignatures).p.IsEqual(signature.p), "%x", bitmap)
+
+		publicKey, err := AggregatePublicKeysSubset(fullPublicKey, publicKeys, bitmap)
+		require.NoError(t, err)
+		assert.True(t, AggregatePublicKeys(subsetPublicKeys).p.IsEqual(publicKey.p), "%x", bitmap)
+	}
+
+	for _, bitmap := range [][]byte{nil, {0xff}, {0xff, 0x07, 0x00}, {0xff, 0x08}} {
+		_, err := AggregateSignaturesSubset(fullSignature, signatures, bitmap)
+		assert.ErrorIs(t, err, ErrBitmapLength, "%x", bitmap)
+
+		_, err = AggregatePublicKeysSubset(fullPublicKey, publicKeys, bitmap)
+		assert.ErrorIs(t, err, ErrBitmapLength, "%x", bitmap)
+	}
+
+	_, err := AggregateSignaturesSubset(&Signature{}, signatures, []byte{0xff, 0x07})
+	assert.ErrorIs(t, err, ErrEmptyAggregationValue)
+}
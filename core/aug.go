@@ -0,0 +1,77 @@
+package core
+
+// augDomainSuffix is appended to GetDomain() to separate message augmentation from the basic scheme
+var augDomainSuffix = []byte("_AUG")
+
+// SignAug signs the message augmented with the serialized public key of the signer, see the message
+// augmentation scheme of the IETF BLS signature draft. It prevents rogue key attacks without proof
+// of possession and allows aggregation of signatures of the same message
+func (p *PrivateKey) SignAug(message []byte) (*Signature, error) {
+	s := hashScratchPool.Get().(*hashScratch)
+	defer hashScratchPool.Put(s)
+
+	g1 := new(G1)
+
+	if err := hashToG1Aug(g1, p.PublicKey(), message, s); err != nil {
+		return nil, err
+	}
+
+	G1Mul(g1, g1, p.p)
+
+	return newSignature(g1), nil
+}
+
+// VerifyAug checks the signature of the message created by SignAug of the owner of the public key
+func (s *Signature) VerifyAug(publicKey *PublicKey, message []byte) bool {
+	if s.p == nil || publicKey.p == nil {
+		return false
+	}
+
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+
+	if err := hashToG1Aug(&v.messagePoint, publicKey, message, &v.hash); err != nil {
+		return false
+	}
+
+	return v.verifyMessagePoint(s.p, publicKey.p)
+}
+
+// AggregateVerifyAug checks the aggregated signature of messages created by SignAug, where messages[i]
+// is signed by the owner of publicKeys[i]. Messages do not have to be distinct
+func AggregateVerifyAug(signature *Signature, publicKeys []*PublicKey, messages [][]byte) bool {
+	if signature.p == nil || len(publicKeys) == 0 || len(publicKeys) != len(messages) {
+		return false
+	}
+
+	s := hashScratchPool.Get().(*hashScratch)
+	defer hashScratchPool.Put(s)
+
+	g1s, g2s := make([]G1, len(publicKeys)+1), make([]G2, len(publicKeys)+1)
+
+	for i, publicKey := range publicKeys {
+		if publicKey.p == nil {
+			return false
+		}
+
+		if err := hashToG1Aug(&g1s[i], publicKey, messages[i], s); err != nil {
+			return false
+		}
+
+		g2s[i] = *publicKey.p
+	}
+
+	G1Neg(&g1s[len(publicKeys)], signature.p)
+	g2s[len(publicKeys)] = *ellipticCurveG2
+
+	return PairingCheck(g1s, g2s)
+}
+
+// hashToG1Aug sets out to HashToG1(publicKey || message) with the augmentation domain, so the function
+// set by SetHashToG1 and the hash cache apply as in the basic scheme
+func hashToG1Aug(out *G1, publicKey *PublicKey, message []byte, s *hashScratch) error {
+	augmented := append(publicKey.Marshal(), message...)
+	domain := append(append([]byte{}, GetDomain()...), augDomainSuffix...)
+
+	return hashToG1DomainInto(out, augmented, domain, s)
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SignAug(t *testing.T) {
+	t.Parallel()
+
+	validTestMsg, invalidTestMsg := testGenRandomBytes(t, messageSize), testGenRandomBytes(t, messageSize)
+
+	blsKeys, err := CreateRandomBlsKeys(3)
+	require.NoError(t, err)
+
+	publicKeys := CollectPublicKeys(blsKeys)
+
+	signature, err := blsKeys[0].SignAug(validTestMsg)
+	require.NoError(t, err)
+
+	assert.True(t, signature.VerifyAug(publicKeys[0], validTestMsg))
+	assert.False(t, signature.VerifyAug(publicKeys[0], invalidTestMsg))
+	assert.False(t, signature.VerifyAug(publicKeys[1], validTestMsg))
+	assert.False(t, (&Signature{}).VerifyAug(publicKeys[0], validTestMsg))
+
+	// the basic scheme signature of the augmented message is not valid because of the domain
+	basic, err := blsKeys[0].Sign(append(publicKeys[0].Marshal(), validTestMsg...))
+	require.NoError(t, err)
+
+	assert.False(t, basic.VerifyAug(publicKeys[0], validTestMsg))
+	assert.False(t, signature.Verify(publicKeys[0], append(publicKeys[0].Marshal(), validTestMsg...)))
+}
+
+func Test_AggregateVerifyAug(t *testing.T) {
+	t.Parallel()
+
+	blsKeys, err := CreateRandomBlsKeys(4)
+	require.NoError(t, err)
+
+	publicKeys := CollectPublicKeys(blsKeys)
+	commonMsg := testGenRandomBytes(t, messageSize)
+
+	// the last two keys sign the same message
+	messages := [][]byte{testGenRandomBytes(t, messageSize), testGenRandomBytes(t, messageSize), commonMsg, commonMsg}
+	signatures := make([]*Signature, len(blsKeys))
+
+	for i, key := range blsKeys {
+		signatures[i], err = key.SignAug(messages[i])
+		require.NoError(t, err)
+	}
+
+	aggregated := AggregateSignatures(signatures)
+
+	assert.True(t, AggregateVerifyAug(aggregated, publicKeys, messages))
+	assert.True(t, AggregateVerifyAug(signatures[0], publicKeys[:1], messages[:1]))
+
+	swapped := [][]byte{messages[1], messages[0], commonMsg, commonMsg}
+	assert.False(t, AggregateVerifyAug(aggregated, publicKeys, swapped))
+	assert.False(t, AggregateVerifyAug(aggregated, publicKeys[1:], messages[1:]))
+	assert.False(t, AggregateVerifyAug(aggregated, publicKeys, messages[1:]))
+	assert.False(t, AggregateVerifyAug(aggregated, nil, nil))
+	assert.False(t, AggregateVerifyAug(aggregated, []*PublicKey{publicKeys[0], {}, publicKeys[2], publicKeys[3]}, messages))
+
+	// the rogue key x G2 - P_honest does not allow to forge the aggregate of the common message
+	rogue := new(G2)
+	G2Sub(rogue, publicKeys[3].p, publicKeys[2].p)
+
+	forged, err := blsKeys[3].SignAug(commonMsg)
+	require.NoError(t, err)
+
+	assert.False(t, AggregateVerifyAug(forged, []*PublicKey{publicKeys[2], newPublicKey(rogue)}, messages[2:]))
+}
+
+// Test_SignAugHashSelection replaces the global hash function and cache, so it must not run in parallel
+// with other tests
+func Test_SignAugHashSelection(t *testing.T) {
+	defer SetHashToG1(nil)
+
+	message := testGenRandomBytes(t, messageSize)
+
+	blsKey, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	publicKey := blsKey.PublicKey()
+	augmented := append(publicKey.Marshal(), message...)
+
+	var hashed [][]byte
+
+	SetHashToG1(func(message []byte) (*G1, error) {
+		hashed = append(hashed, message)
+
+		return HashToG103(message)
+	})
+
+	signature, err := blsKey.SignAug(message)
+	require.NoError(t, err)
+
+	// the custom function maps the augmented message, the signature is over its point
+	expected, err := HashToG103(augmented)
+	require.NoError(t, err)
+
+	G1Mul(expected, expected, blsKey.p)
+	assert.True(t, expected.IsEqual(signature.p))
+
+	assert.True(t, signature.VerifyAug(publicKey, message))
+	assert.True(t, AggregateVerifyAug(signature, []*PublicKey{publicKey}, [][]byte{message}))
+	assert.Equal(t, [][]byte{augmented, augmented, augmented}, hashed)
+
+	// the default function does not verify signatures of the custom one
+	SetHashToG1(nil)
+
+	assert.False(t, signature.VerifyAug(publicKey, message))
+
+	SetHashCacheSize(2)
+	defer SetHashCacheSize(0)
+
+	signature, err = blsKey.SignAug(message)
+	require.NoError(t, err)
+
+	assert.True(t, signature.VerifyAug(publicKey, message))
+	assert.True(t, AggregateVerifyAug(signature, []*PublicKey{publicKey}, [][]byte{message}))
+	assert.Equal(t, HashCacheStats{Hits: 2, Misses: 1, Size: 1, Capacity: 2}, GetHashCacheStats())
+
+	// the augmentation domain is a part of the key, the basic scheme misses the cache
+	_, err = blsKey.Sign(augmented)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(2), GetHashCacheStats().Misses)
+}
@@ -0,0 +1,5 @@
+//go:build purego
+
+package core
+
+const testBackendName = "purego"
@@ -0,0 +1,196 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const backendVectorsPath = "testdata/backend_vectors.json"
+
+var updateBackendVectors = flag.Bool("update-backend-vectors", false,
+	"regenerate "+backendVectorsPath+" (only with the mcl backend)")
+
+// backendVector holds outputs of the mcl backend for deterministic inputs. Every backend
+// must reproduce them byte for byte
+type backendVector struct {
+	Seed           string `json:"seed"`
+	PrivateKey     string `json:"privateKey"`
+	Message        string `json:"message"`
+	PublicKey      string `json:"publicKey"`
+	Signature      string `json:"signature"`
+	MessagePoint   string `json:"messagePoint"`
+	HashAndMapToG1 string `json:"hashAndMapToG1"`
+	HashAndMapToG2 string `json:"hashAndMapToG2"`
+	MapToG1        string `json:"mapToG1"`
+	MapToG2        string `json:"mapToG2"`
+	Pairing        string `json:"pairing"`
+	FpSquareRoot   string `json:"fpSquareRoot"`
+	FrSquareRoot   string `json:"frSquareRoot"`
+	Fp2SquareRoot  string `json:"fp2SquareRoot"`
+}
+
+func Test_BackendVectors(t *testing.T) {
+	t.Parallel()
+
+	if *updateBackendVectors {
+		testWriteBackendVectors(t)
+	}
+
+	buf, err := os.ReadFile(backendVectorsPath)
+	require.NoError(t, err)
+
+	var vectors []backendVector
+
+	require.NoError(t, json.Unmarshal(buf, &vectors))
+	require.NotEmpty(t, vectors)
+
+	for _, v := range vectors {
+		assert.Equal(t, v, testComputeBackendVector(t, v.Seed), v.Seed)
+	}
+}
+
+func testWriteBackendVectors(t *testing.T) {
+	t.Helper()
+
+	if testBackendName != "mcl" {
+		t.Fatalf("backend vectors must be generated with the mcl backend, not %s", testBackendName)
+	}
+
+	vectors := make([]backendVector, 8)
+	for i := range vectors {
+		vectors[i] = testComputeBackendVector(t, fmt.Sprintf("vector %d", i))
+	}
+
+	buf, err := json.MarshalIndent(vectors, "", "  ")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(backendVectorsPath, append(buf, '\n'), 0600))
+}
+
+// testComputeBackendVector derives all inputs from the seed
+func testComputeBackendVector(t *testing.T, seed string) backendVector {
+	t.Helper()
+
+	hash := func(tag string) []byte {
+		h := sha256.Sum256([]byte(seed + "/" + tag))
+
+		return h[:]
+	}
+
+	fr := new(Fr)
+	fr.SetHashOf(hash("private key"))
+
+	sk := &PrivateKey{p: fr}
+	message := append(hash("message"), hash("message 2")[:len(seed)]...)
+
+	signature, err := sk.Sign(message)
+	require.NoError(t, err)
+
+	sigBytes, err := signature.Marshal()
+	require.NoError(t, err)
+
+	messagePoint, err := MarshalMessage(message)
+	require.NoError(t, err)
+
+	v := backendVector{
+		Seed:         seed,
+		PrivateKey:   hex.EncodeToString(fr.Serialize()),
+		Message:      hex.EncodeToString(message),
+		PublicKey:    hex.EncodeToString(sk.PublicKey().Marshal()),
+		Signature:    hex.EncodeToString(sigBytes),
+		MessagePoint: hex.EncodeToString(messagePoint),
+	}
+
+	g1, g2 := new(G1), new(G2)
+
+	require.NoError(t, g1.HashAndMapTo(message))
+	require.NoError(t, g2.HashAndMapTo(message))
+
+	v.HashAndMapToG1, v.HashAndMapToG2 = g1.GetString(10), g2.GetString(10)
+
+	var fp, root Fp
+
+	fp.SetHashOf(hash("fp"))
+	require.NoError(t, MapToG1(g1, &fp))
+
+	var fp2, root2 Fp2
+
+	fp2.D[0].SetHashOf(hash("fp2 a"))
+	fp2.D[1].SetHashOf(hash("fp2 b"))
+	require.NoError(t, MapToG2(g2, &fp2))
+
+	v.MapToG1, v.MapToG2 = g1.GetString(10), g2.GetString(10)
+
+	gt := new(GT)
+	Pairing(gt, signature.p, sk.PublicKey().p)
+	v.Pairing = gt.GetString(10)
+
+	FpSqr(&fp, &fp)
+	require.True(t, FpSquareRoot(&root, &fp))
+	v.FpSquareRoot = root.GetString(10)
+
+	var frRoot Fr
+
+	FrSqr(fr, fr)
+	require.True(t, FrSquareRoot(&frRoot, fr))
+	v.FrSquareRoot = frRoot.GetString(10)
+
+	Fp2Sqr(&fp2, &fp2)
+	require.True(t, Fp2SquareRoot(&root2, &fp2))
+	v.Fp2SquareRoot = root2.D[0].GetString(10) + " " + root2.D[1].GetString(10)
+
+	return v
+}
+
+func Test_PrecomputedMillerLoop2(t *testing.T) {
+	t.Parallel()
+
+	p1 := testHashToG1(t)
+
+	var p2 G1
+
+	G1Neg(&p2, p1)
+
+	var e GT
+
+	// e(P, Q) e(-P, Q) = 1 and e(P, Q) e(P, Q) = e(2P, Q)
+	PrecomputedMillerLoop2(&e, p1, qCoef, &p2, qCoef)
+	FinalExp(&e, &e)
+	assert.True(t, e.IsOne())
+
+	var p3 G1
+
+	G1Dbl(&p3, p1)
+	PrecomputedMillerLoop2(&e, p1, qCoef, p1, qCoef)
+	FinalExp(&e, &e)
+
+	var expected GT
+
+	Pairing(&expected, &p3, ellipticCurveG2)
+	assert.True(t, expected.IsEqual(&e))
+
+	// distinct points and coefficients, P2 and Q2 must not be replaced by P1 and Q1
+	var (
+		x      Fr
+		q2     G2
+		e1, e2 GT
+	)
+
+	require.True(t, x.SetByCSPRNG())
+	G1MulGenerator(&p2, &x)
+	G2MulGenerator(&q2, &x)
+
+	PrecomputedMillerLoop2(&e, p1, qCoef, &p2, PrecomputeG2(&q2))
+
+	MillerLoop(&e1, p1, ellipticCurveG2)
+	MillerLoop(&e2, &p2, &q2)
+	GTMul(&expected, &e1, &e2)
+	assert.True(t, expected.IsEqual(&e))
+}
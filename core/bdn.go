@@ -0,0 +1,100 @@
+package core
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// bdnCoefficientSize is the size of BDN coefficients in bytes, 128 bits are enough against rogue keys
+const bdnCoefficientSize = 16
+
+var bdnDomain = []byte("gocrmcl BDN aggregation")
+
+// AggregatePublicKeysBDN calculates a_1 P1 + a_2 P2 + ... of the Boneh-Drijvers-Neven multisignature,
+// where a_i = H(P_i, {P_1, ..., P_n}). Unlike AggregatePublicKeys it is secure against rogue keys without
+// proof of possession. Signatures aggregated by AggregateSignaturesBDN with the same keys in the same
+// order are verified against the aggregated key with Signature.Verify
+func AggregatePublicKeysBDN(pubs []*PublicKey) (*PublicKey, error) {
+	coefs, err := bdnCoefficients(pubs)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]G2, len(pubs))
+
+	for i, pub := range pubs {
+		points[i] = *pub.p
+	}
+
+	res := new(G2)
+
+	G2MulVec(res, points, coefs)
+
+	return newPublicKey(res), nil
+}
+
+// AggregateSignaturesBDN calculates a_1 S1 + a_2 S2 + ... of the Boneh-Drijvers-Neven multisignature,
+// where S_i is the signature of the owner of pubs[i] and a_i are coefficients of AggregatePublicKeysBDN
+func AggregateSignaturesBDN(signatures []*Signature, pubs []*PublicKey) (*Signature, error) {
+	if len(signatures) != len(pubs) {
+		return nil, fmt.Errorf("%w: %d signatures, %d public keys", ErrAggregationLength, len(signatures), len(pubs))
+	}
+
+	coefs, err := bdnCoefficients(pubs)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]G1, len(signatures))
+
+	for i, signature := range signatures {
+		if signature == nil || signature.p == nil {
+			return nil, fmt.Errorf("%w: signature %d", ErrEmptyAggregationValue, i)
+		}
+
+		points[i] = *signature.p
+	}
+
+	res := new(G1)
+
+	G1MulVec(res, points, coefs)
+
+	return newSignature(res), nil
+}
+
+// bdnCoefficients calculates a_i = H(P_i, {P_1, ..., P_n}) as the first 128 bits of
+// SHA-256(domain || P_i || SHA-256(P_1 || ... || P_n))
+func bdnCoefficients(pubs []*PublicKey) ([]Fr, error) {
+	if len(pubs) == 0 {
+		return nil, ErrEmptyAggregation
+	}
+
+	raw := make([][]byte, len(pubs))
+	all := sha256.New()
+
+	for i, pub := range pubs {
+		if pub == nil || pub.p == nil {
+			return nil, fmt.Errorf("%w: public key %d", ErrEmptyAggregationValue, i)
+		}
+
+		raw[i] = pub.Marshal()
+		_, _ = all.Write(raw[i])
+	}
+
+	set := all.Sum(nil)
+	coefs := make([]Fr, len(pubs))
+	h := sha256.New()
+
+	for i := range pubs {
+		h.Reset()
+		_, _ = h.Write(bdnDomain)
+		_, _ = h.Write(raw[i])
+		_, _ = h.Write(set)
+
+		if err := coefs[i].SetBigEndianMod(h.Sum(nil)[:bdnCoefficientSize]); err != nil {
+			return nil, err
+		}
+	}
+
+	return coefs, nil
+}
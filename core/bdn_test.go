@@ -0,0 +1,88 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AggregateBDN(t *testing.T) {
+	t.Parallel()
+
+	validTestMsg, invalidTestMsg := testGenRandomBytes(t, messageSize), testGenRandomBytes(t, messageSize)
+
+	blsKeys, err := CreateRandomBlsKeys(4)
+	require.NoError(t, err)
+
+	publicKeys := CollectPublicKeys(blsKeys)
+	signatures := make([]*Signature, len(blsKeys))
+
+	for i, key := range blsKeys {
+		signatures[i], err = key.Sign(validTestMsg)
+		require.NoError(t, err)
+	}
+
+	publicKey, err := AggregatePublicKeysBDN(publicKeys)
+	require.NoError(t, err)
+
+	signature, err := AggregateSignaturesBDN(signatures, publicKeys)
+	require.NoError(t, err)
+
+	assert.True(t, signature.Verify(publicKey, validTestMsg))
+	assert.False(t, signature.Verify(publicKey, invalidTestMsg))
+
+	// weighted aggregation differs from the naive one
+	assert.False(t, signature.VerifyAggregated(publicKeys, validTestMsg))
+	assert.False(t, AggregateSignatures(signatures).Verify(publicKey, validTestMsg))
+
+	// coefficients depend on the whole set of keys
+	subsetPublicKey, err := AggregatePublicKeysBDN(publicKeys[:3])
+	require.NoError(t, err)
+
+	subsetSignature, err := AggregateSignaturesBDN(signatures[:3], publicKeys[:3])
+	require.NoError(t, err)
+
+	assert.True(t, subsetSignature.Verify(subsetPublicKey, validTestMsg))
+	assert.False(t, signature.Verify(subsetPublicKey, validTestMsg))
+
+	_, err = AggregatePublicKeysBDN(nil)
+	assert.ErrorIs(t, err, ErrEmptyAggregation)
+
+	_, err = AggregatePublicKeysBDN([]*PublicKey{publicKeys[0], {}})
+	assert.ErrorIs(t, err, ErrEmptyAggregationValue)
+
+	_, err = AggregateSignaturesBDN(signatures[:2], publicKeys)
+	assert.ErrorIs(t, err, ErrAggregationLength)
+
+	_, err = AggregateSignaturesBDN([]*Signature{signatures[0], nil}, publicKeys[:2])
+	assert.ErrorIs(t, err, ErrEmptyAggregationValue)
+}
+
+func Test_AggregateBDNRogueKey(t *testing.T) {
+	t.Parallel()
+
+	message := testGenRandomBytes(t, messageSize)
+
+	honestKey, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	attackerKey, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	// the rogue key x G2 - P_honest makes the naive aggregate x G2 known to the attacker
+	rogue := new(G2)
+	G2Sub(rogue, attackerKey.PublicKey().p, honestKey.PublicKey().p)
+
+	publicKeys := []*PublicKey{honestKey.PublicKey(), newPublicKey(rogue)}
+
+	forged, err := attackerKey.Sign(message)
+	require.NoError(t, err)
+
+	assert.True(t, forged.VerifyAggregated(publicKeys, message))
+
+	publicKey, err := AggregatePublicKeysBDN(publicKeys)
+	require.NoError(t, err)
+
+	assert.False(t, forged.Verify(publicKey, message))
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrOutOfRange is returned when integer is negative or not less than the modulus of the field
+var ErrOutOfRange = errors.New("integer is out of the field range")
+
+// CurveParams holds parameters of the initialized curve
+type CurveParams struct {
+	// Curve is CurveSNARK1 or CurveFp254BNb
+	Curve int
+	// FieldOrder is the characteristic p of the base field Fp
+	FieldOrder *big.Int
+	// CurveOrder is the order r of G1, G2 and the scalar field Fr
+	CurveOrder *big.Int
+	// G1Generator and G2Generator are normalized generators used for key generation
+	G1Generator *G1
+	G2Generator *G2
+}
+
+// GetCurveParams returns parameters of the initialized curve. Returned values are copies
+// and may be modified by the caller
+func GetCurveParams() *CurveParams {
+	return &CurveParams{
+		Curve:       GetCurveType(),
+		FieldOrder:  new(big.Int).Set(fieldOrder),
+		CurveOrder:  new(big.Int).Set(curveOrder),
+		G1Generator: G1Generator(),
+		G2Generator: G2Generator(),
+	}
+}
+
+// G1Generator returns a copy of the G1 generator of the initialized curve
+func G1Generator() *G1 {
+	g := *ellipticCurveG1
+
+	return &g
+}
+
+// G2Generator returns a copy of the G2 generator of the initialized curve which public keys are derived from
+func G2Generator() *G2 {
+	g := *ellipticCurveG2
+
+	return &g
+}
+
+// SetBigInt sets x to v which must be in range [0, r)
+func (x *Fr) SetBigInt(v *big.Int) error {
+	if v.Sign() < 0 || v.Cmp(curveOrder) >= 0 {
+		return fmt.Errorf("%w: %s", ErrOutOfRange, v)
+	}
+
+	return x.Deserialize(reverseBytes(v.FillBytes(make([]byte, 32))))
+}
+
+// BigInt returns x as an integer in range [0, r)
+func (x *Fr) BigInt() *big.Int {
+	return new(big.Int).SetBytes(reverseBytes(x.Serialize()))
+}
+
+// Bytes32 returns 32 bytes big-endian representation of x, i.e. EVM uint256
+func (x *Fr) Bytes32() [32]byte {
+	var res [32]byte
+
+	copy(res[:], reverseBytes(x.Serialize()))
+
+	return res
+}
+
+// SetBytes32 sets x from 32 bytes big-endian representation. Values which are not less than r are rejected
+func (x *Fr) SetBytes32(raw [32]byte) error {
+	if err := x.Deserialize(reverseBytes(raw[:])); err != nil {
+		return fmt.Errorf("%w: %x", ErrNonCanonical, raw)
+	}
+
+	return nil
+}
+
+// SetBigInt sets x to v which must be in range [0, p)
+func (x *Fp) SetBigInt(v *big.Int) error {
+	if v.Sign() < 0 || v.Cmp(fieldOrder) >= 0 {
+		return fmt.Errorf("%w: %s", ErrOutOfRange, v)
+	}
+
+	return fpFromBigEndian(x, v.FillBytes(make([]byte, 32)))
+}
+
+// BigInt returns x as an integer in range [0, p)
+func (x *Fp) BigInt() *big.Int {
+	return new(big.Int).SetBytes(fpToBigEndian(x))
+}
+
+// Bytes32 returns 32 bytes big-endian representation of x, i.e. EVM uint256
+func (x *Fp) Bytes32() [32]byte {
+	var res [32]byte
+
+	copy(res[:], fpToBigEndian(x))
+
+	return res
+}
+
+// SetBytes32 sets x from 32 bytes big-endian representation. Values which are not less than p are rejected
+func (x *Fp) SetBytes32(raw [32]byte) error {
+	return fpFromBigEndian(x, raw[:])
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FrBigInt(t *testing.T) {
+	t.Parallel()
+
+	r := GetCurveParams().CurveOrder
+
+	for _, v := range []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		new(big.Int).Lsh(big.NewInt(1), 200),
+		new(big.Int).Sub(r, big.NewInt(1)),
+	} {
+		var x Fr
+
+		require.NoError(t, x.SetBigInt(v))
+		assert.Equal(t, v.String(), x.GetString(10))
+		assert.Equal(t, 0, v.Cmp(x.BigInt()))
+
+		raw := x.Bytes32()
+		assert.Equal(t, v.FillBytes(make([]byte, 32)), raw[:])
+
+		var y Fr
+
+		require.NoError(t, y.SetBytes32(raw))
+		assert.True(t, x.IsEqual(&y))
+	}
+
+	var x Fr
+
+	assert.ErrorIs(t, x.SetBigInt(r), ErrOutOfRange)
+	assert.ErrorIs(t, x.SetBigInt(big.NewInt(-1)), ErrOutOfRange)
+
+	var raw [32]byte
+
+	r.FillBytes(raw[:])
+	assert.ErrorIs(t, x.SetBytes32(raw), ErrNonCanonical)
+}
+
+func Test_FpBigInt(t *testing.T) {
+	t.Parallel()
+
+	p := GetCurveParams().FieldOrder
+
+	for _, v := range []*big.Int{
+		big.NewInt(0),
+		big.NewInt(2),
+		new(big.Int).Lsh(big.NewInt(1), 253),
+		new(big.Int).Sub(p, big.NewInt(1)),
+	} {
+		var x Fp
+
+		require.NoError(t, x.SetBigInt(v))
+		assert.Equal(t, v.String(), x.GetString(10))
+		assert.Equal(t, 0, v.Cmp(x.BigInt()))
+
+		raw := x.Bytes32()
+		assert.Equal(t, v.FillBytes(make([]byte, 32)), raw[:])
+
+		var y Fp
+
+		require.NoError(t, y.SetBytes32(raw))
+		assert.True(t, x.IsEqual(&y))
+	}
+
+	var x Fp
+
+	assert.ErrorIs(t, x.SetBigInt(p), ErrOutOfRange)
+	assert.ErrorIs(t, x.SetBigInt(big.NewInt(-1)), ErrOutOfRange)
+
+	var raw [32]byte
+
+	p.FillBytes(raw[:])
+	assert.ErrorIs(t, x.SetBytes32(raw), ErrNonCanonical)
+}
+
+func Test_GetCurveParams(t *testing.T) {
+	t.Parallel()
+
+	params := GetCurveParams()
+
+	assert.Equal(t, CurveSNARK1, params.Curve)
+	assert.Equal(t, GetFieldOrder(), params.FieldOrder.String())
+	assert.Equal(t, GetCurveOrder(), params.CurveOrder.String())
+
+	// G1 generator of the EVM alt_bn128 curve is (1, 2)
+	assert.Equal(t, "1 1 2", params.G1Generator.GetString(10))
+	assert.True(t, params.G2Generator.IsEqual(ellipticCurveG2))
+
+	// returned values do not alias the package state
+	params.CurveOrder.SetInt64(0)
+	G1Dbl(params.G1Generator, params.G1Generator)
+
+	assert.Equal(t, GetCurveOrder(), GetCurveParams().CurveOrder.String())
+	assert.Equal(t, "1 1 2", G1Generator().GetString(10))
+}
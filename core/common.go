@@ -2,54 +2,144 @@ package core
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/big"
 )
 
+// curveGenerators holds affine G1 and G2 generators of the supported curves in G1/G2 SetString format.
+// mcl does not export generators of BN curves (mclBnG1_getBasePoint returns zero for them), so initCurve
+// checks with the backend that they are points of order r of the initialized curve
+var curveGenerators = map[int]struct {
+	g1, g2 string
+}{
+	CurveSNARK1: {
+		g1: "1 1 2",
+		g2: "1 10857046999023057135944570762232829481370756359578518086990519993285655852781 " +
+			"11559732032986387107991004021392285783925812861821192530917403151452391805634 " +
+			"8495653923123431417604973247489272438418190587263600148770280649306958101930 " +
+			"4082367875863433681332203403145435568316851327593401208105741076214120093531",
+	},
+	CurveFp254BNb: {
+		g1: "1 -1 1",
+		g2: "1 12723517038133731887338407189719511622662176727675373276651903807414909099441 " +
+			"4168783608814932154536427934509895782246573715297911553964171371032945126671 " +
+			"13891744915211034074451795021214165905772212241412891944830863846330766296736 " +
+			"7937318970632701341203597196594272556916396164729705624521405069090520231616",
+	},
+}
+
+var errUnsupportedCurve = errors.New("unsupported curve")
+
+const defaultDomainHex = "608e30424791cb9a71683381558c3da1979b6fa423b2d6db1396b1d94d7c4a78"
+
 var (
-	domain, _ = hex.DecodeString("608e30424791cb9a71683381558c3da1979b6fa423b2d6db1396b1d94d7c4a78")
-
-	ellipticCurveG2 = &G2{
-		X: Fp2{
-			[2]Fp{
-				newFp(0x8e83b5d102bc2026, 0xdceb1935497b0172, 0xfbb8264797811adf, 0x19573841af96503b),
-				newFp(0xafb4737da84c6140, 0x6043dd5a5802d8c4, 0x09e950fc52a02f86, 0x14fef0833aea7b6b),
-			},
-		},
-		Y: Fp2{
-			[2]Fp{
-				newFp(0x619dfa9d886be9f6, 0xfe7fd297f59e9b78, 0xff9e1a62231b7dfe, 0x28fd7eebae9e4206),
-				newFp(0x64095b56c71856ee, 0xdc57f922327d3cbb, 0x55f935be33351076, 0x0da4a0e693fd6482),
-			},
-		},
-		Z: Fp2{
-			[2]Fp{
-				newFp(0xd35d438dc58f0d9d, 0x0a78eb28f5c70b3d, 0x666ea36f7879462c, 0x0e0a77c19a07df2f),
-				newFp(0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000),
-			},
-		},
-	}
+	domain, _ = hex.DecodeString(defaultDomainHex)
+
+	ellipticCurveG1 = new(G1)
+	ellipticCurveG2 = new(G2)
+
+	// fieldOrder and curveOrder are p and r of the initialized curve
+	fieldOrder, curveOrder *big.Int
 
-	r1 = newFp(0xd35d438dc58f0d9d, 0x0a78eb28f5c70b3d, 0x666ea36f7879462c, 0x0e0a77c19a07df2f)
+	// r1 and r2 are 1 and 2^256 mod p, i.e. R and R^2 mod p in Montgomery form
+	r1, r2 Fp
 
-	r2 = newFp(0xf32cfc5b538afa89, 0xb5e71911d44501fb, 0x47ab1eff0a417ff6, 0x06d89f71cab8351f)
+	// f192 is 2^192 used to combine two 24 byte halves of hash output into a field element
+	f192 Fp
 
 	qCoef []uint64
 
-	HashToG1 func([]byte) (*G1, error)
+	// g1B and g2B are b coefficients of the G1 curve y^2 = x^3 + b and its G2 twist
+	g1B Fp
+	g2B Fp2
+
+	// customHashToG1 is the function set by SetHashToG1, nil selects the default HashToG107
+	customHashToG1 func([]byte) (*G1, error)
+
+	// initErr is the result of the last curve initialization, including the one on import
+	initErr error
 )
 
 func init() {
-	if err := InitCurve(CurveSNARK1); err != nil {
-		panic(fmt.Errorf("snark1 curve initialization error: %w", err))
+	// importing the package must not crash the binary, initErr is reported by SelfTest
+	// and Initialize initializes the curve again
+	initErr = Init(CurveSNARK1)
+}
+
+// Init initializes the given curve and derives all curve dependent constants from it.
+// CurveSNARK1 is initialized by default, CurveFp254BNb is supported as well.
+// It must not be called concurrently with any other function of the package
+func Init(curve int) error {
+	if _, ok := curveGenerators[curve]; !ok {
+		// the initialized curve is left intact
+		return fmt.Errorf("%w: %d", errUnsupportedCurve, curve)
+	}
+
+	initErr = initCurve(curve)
+
+	return initErr
+}
+
+func initCurve(curve int) error {
+	generators := curveGenerators[curve]
+
+	if err := InitCurve(curve); err != nil {
+		return err
 	}
 
 	if err := SetMapToMode(0); err != nil {
-		panic(fmt.Errorf("snark1 curve map to mode: %w", err))
+		return fmt.Errorf("curve %d map to mode: %w", curve, err)
+	}
+
+	g1, g2 := new(G1), new(G2)
+
+	if err := g1.SetString(generators.g1, 10); err != nil {
+		return fmt.Errorf("curve %d G1 generator: %w", curve, err)
+	}
+
+	if err := g2.SetString(generators.g2, 10); err != nil {
+		return fmt.Errorf("curve %d G2 generator: %w", curve, err)
+	}
+
+	if g1.IsZero() || !g1.IsValid() || !g1.IsValidOrder() {
+		return fmt.Errorf("curve %d G1 generator is not a point of order r", curve)
+	}
+
+	if g2.IsZero() || !g2.IsValid() || !g2.IsValidOrder() {
+		return fmt.Errorf("curve %d G2 generator is not a point of order r", curve)
+	}
+
+	p, ok := new(big.Int).SetString(GetFieldOrder(), 10)
+	if !ok {
+		return fmt.Errorf("curve %d invalid field order %s", curve, GetFieldOrder())
 	}
 
-	qCoef = PrecomputeG2(ellipticCurveG2)
+	r, ok := new(big.Int).SetString(GetCurveOrder(), 10)
+	if !ok {
+		return fmt.Errorf("curve %d invalid curve order %s", curve, GetCurveOrder())
+	}
+
+	r1.SetInt64(1)
+
+	if err := r2.SetString(new(big.Int).Mod(new(big.Int).Lsh(big.NewInt(1), 256), p).String(), 10); err != nil {
+		return fmt.Errorf("curve %d r2: %w", curve, err)
+	}
+
+	if err := f192.SetString(new(big.Int).Lsh(big.NewInt(1), 192).String(), 10); err != nil {
+		return fmt.Errorf("curve %d 2^192: %w", curve, err)
+	}
+
+	fieldOrder, curveOrder = p, r
+	ellipticCurveG1, ellipticCurveG2 = g1, g2
+	fixedBaseGenerators = new(generatorTables)
+	hashCache.reset()
+	qCoef = PrecomputeG2(g2)
 
-	HashToG1 = HashToG107
+	g1WeierstrassB(&g1B, g1)
+	g2WeierstrassB(&g2B, g2)
+
+	return nil
 }
 
 func SetDomain(_domain []byte) {
@@ -61,6 +151,21 @@ func GetDomain() []byte {
 	return domain
 }
 
+// SetHashToG1 sets the function which maps messages to G1, nil restores the default HashToG107.
+// It replaces assignment of the former HashToG1 variable
+func SetHashToG1(fn func([]byte) (*G1, error)) {
+	customHashToG1 = fn
+}
+
+// HashToG1 maps the message to G1 with the function set by SetHashToG1, HashToG107 by default
+func HashToG1(message []byte) (*G1, error) {
+	if customHashToG1 != nil {
+		return customHashToG1(message)
+	}
+
+	return HashToG107(message)
+}
+
 func GetCoef() []uint64 {
 	return qCoef
 }
@@ -93,3 +198,40 @@ func G2Zero(g2 *G2) *G2 {
 
 	return g2
 }
+
+// g1WeierstrassB calculates b = y^2 - x^3 for the given point
+func g1WeierstrassB(out *Fp, p *G1) {
+	var np G1
+
+	G1Normalize(&np, p)
+
+	x3 := new(Fp)
+
+	FpSqr(x3, &np.X)
+	FpMul(x3, x3, &np.X)
+	FpSqr(out, &np.Y)
+	FpSub(out, out, x3)
+}
+
+// g2WeierstrassB calculates b = y^2 - x^3 for the given point of the twist
+func g2WeierstrassB(out *Fp2, p *G2) {
+	var np G2
+
+	G2Normalize(&np, p)
+
+	x3 := new(Fp2)
+
+	Fp2Sqr(x3, &np.X)
+	Fp2Mul(x3, x3, &np.X)
+	Fp2Sqr(out, &np.Y)
+	Fp2Sub(out, out, x3)
+}
+
+// checkVecSize returns the common length of slice arguments of vector operations
+func checkVecSize(out, x, y int) int {
+	if out != x || x != y {
+		panic("out, xVec and yVec have the same size")
+	}
+
+	return out
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_InitFp254BNb switches the global curve, so it must not run in parallel with other tests
+func Test_InitFp254BNb(t *testing.T) {
+	require.NoError(t, Init(CurveFp254BNb))
+
+	defer func() {
+		require.NoError(t, Init(CurveSNARK1))
+	}()
+
+	assert.True(t, ellipticCurveG1.IsValidOrder())
+	assert.True(t, ellipticCurveG2.IsValidOrder())
+	assert.Equal(t, "2", g1B.GetString(10))
+	assert.True(t, r1.IsOne())
+
+	validTestMsg, invalidTestMsg := testGenRandomBytes(t, messageSize), testGenRandomBytes(t, messageSize)
+
+	blsKeys, err := CreateRandomBlsKeys(3)
+	require.NoError(t, err)
+
+	signatures := make([]*Signature, len(blsKeys))
+
+	for i, key := range blsKeys {
+		signatures[i], err = key.Sign(validTestMsg)
+		require.NoError(t, err)
+
+		messagePoint, err := HashToG1(validTestMsg)
+		require.NoError(t, err)
+		assert.True(t, messagePoint.IsValid())
+	}
+
+	publicKeys := CollectPublicKeys(blsKeys)
+	signature := AggregateSignatures(signatures)
+
+	assert.True(t, signature.VerifyAggregated(publicKeys, validTestMsg))
+	assert.False(t, signature.VerifyAggregated(publicKeys, invalidTestMsg))
+	assert.False(t, signatures[0].Verify(publicKeys[1], validTestMsg))
+
+	sigBytes, err := signature.Marshal()
+	require.NoError(t, err)
+
+	unmarshaledSig, err := UnmarshalSignature(sigBytes)
+	require.NoError(t, err)
+
+	unmarshaledPub, err := UnmarshalPublicKey(AggregatePublicKeys(publicKeys).Marshal())
+	require.NoError(t, err)
+
+	assert.True(t, unmarshaledSig.Verify(unmarshaledPub, validTestMsg))
+}
+
+func Test_InitUnsupportedCurve(t *testing.T) {
+	t.Parallel()
+
+	for _, curve := range []int{CurveFp382_1, CurveFp382_2, BLS12_381} {
+		assert.ErrorIs(t, Init(curve), errUnsupportedCurve)
+	}
+}
+
+// Test_CurveGenerators replaces the generator table, so it must not run in parallel with other tests
+func Test_CurveGenerators(t *testing.T) {
+	// SNARK1 generators match gnark-crypto, see testdata/interopgen
+	vectors := testLoadInteropVectors(t)
+
+	assert.Equal(t, "generator", vectors.G1[0].Name)
+	assert.Equal(t, "1 "+vectors.G1[0].X+" "+vectors.G1[0].Y, curveGenerators[CurveSNARK1].g1)
+	assert.Equal(t, "generator", vectors.G2[0].Name)
+	assert.Equal(t, "1 "+vectors.G2[0].X+" "+vectors.G2[0].Y, curveGenerators[CurveSNARK1].g2)
+
+	// a point of the twist which is not in G2, the cofactor of G2 is not one
+	var (
+		x       int64
+		outside G2
+	)
+
+	for x = 1; !g2RecoverYFromX(&outside, x); x++ {
+	}
+
+	require.False(t, outside.IsValidOrder())
+
+	saved := curveGenerators[CurveSNARK1]
+
+	defer func() {
+		curveGenerators[CurveSNARK1] = saved
+
+		require.NoError(t, Init(CurveSNARK1))
+	}()
+
+	// a generator which drifted from the curve of the backend is rejected on initialization
+	for _, generators := range []struct{ g1, g2 string }{
+		{"1 1 3", saved.g2},
+		{saved.g1, saved.g2[:len(saved.g2)-1] + "2"},
+		{saved.g1, outside.GetString(10)},
+	} {
+		curveGenerators[CurveSNARK1] = generators
+
+		assert.Error(t, Init(CurveSNARK1))
+	}
+}
+
+// g2RecoverYFromX sets out to a point of the twist with X = x if there is one
+func g2RecoverYFromX(out *G2, x int64) bool {
+	out.Clear()
+	out.X.D[0].SetInt64(x)
+	out.Z.D[0].SetInt64(1)
+
+	return g2RecoverY(out)
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_ConcurrentSharedKeys exercises shared public keys and signatures from many goroutines,
+// run it with -race to detect hidden mutations of the internal points. Writes done by mcl are invisible
+// to the race detector, so it is meaningful with the pure Go backend. It does not run in parallel
+// with other tests which keeps the race detector reports reliable
+func Test_ConcurrentSharedKeys(t *testing.T) {
+	const goroutines = 8
+
+	message := testGenRandomBytes(t, messageSize)
+
+	blsKeys, err := CreateRandomBlsKeys(3)
+	require.NoError(t, err)
+
+	publicKeys := CollectPublicKeys(blsKeys)
+	signatures := make([]*Signature, len(blsKeys))
+
+	for i, key := range blsKeys {
+		signatures[i], err = key.Sign(message)
+		require.NoError(t, err)
+	}
+
+	// aggregated values are shared like the single ones, they are normalized at construction
+	aggregatedPublicKey := AggregatePublicKeys(publicKeys)
+	aggregatedSignature := signatures[0].Aggregate(signatures[1]).Aggregate(signatures[2])
+
+	// expected encodings are computed from separate values, so the shared ones are untouched
+	expectedPublicKey := AggregatePublicKeys(publicKeys).Marshal()
+	expectedSignature, err := AggregateSignatures(signatures).Marshal()
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	results := make([]bool, 2*goroutines)
+
+	// serialization and verification run in separate goroutines, so the race detector
+	// keeps enough history of the short serialization goroutines to report a race
+	for i := 0; i < goroutines; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			rawSignature, err := aggregatedSignature.Marshal()
+			ok := err == nil && string(rawSignature) == string(expectedSignature)
+			ok = ok && string(aggregatedPublicKey.Marshal()) == string(expectedPublicKey)
+
+			_, err = aggregatedSignature.MarshalCompressed()
+			ok = ok && err == nil
+			_, err = aggregatedSignature.MarshalEVM()
+			ok = ok && err == nil
+			_, err = aggregatedPublicKey.MarshalJSON()
+			ok = ok && err == nil
+
+			_ = aggregatedPublicKey.MarshalCompressed()
+			_ = aggregatedPublicKey.MarshalEVM()
+			_ = aggregatedPublicKey.String()
+			_ = aggregatedSignature.String()
+
+			results[2*i] = ok
+		}(i)
+
+		go func(i int) {
+			defer wg.Done()
+
+			_ = aggregatedPublicKey.Aggregate(publicKeys[0])
+			_ = aggregatedSignature.Aggregate(signatures[0])
+			_ = AggregateSignatures(signatures)
+
+			results[2*i+1] = aggregatedSignature.Verify(aggregatedPublicKey, message) &&
+				aggregatedSignature.VerifyAggregated(publicKeys, message) &&
+				signatures[i%len(signatures)].Verify(publicKeys[i%len(publicKeys)], message)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, ok := range results {
+		assert.True(t, ok, i)
+	}
+}
+
+func Test_SerializationDoesNotModifyPoint(t *testing.T) {
+	t.Parallel()
+
+	var (
+		x  Fr
+		p1 G1
+		p2 G2
+	)
+
+	require.True(t, x.SetByCSPRNG())
+
+	// sums are not normalized
+	G1Mul(&p1, ellipticCurveG1, &x)
+	G1Add(&p1, &p1, ellipticCurveG1)
+	G2Mul(&p2, ellipticCurveG2, &x)
+	G2Add(&p2, &p2, ellipticCurveG2)
+
+	c1, c2 := p1, p2
+
+	_ = G1ToBytes(&p1)
+	_ = G2ToBytes(&p2)
+
+	assert.Equal(t, c1, p1)
+	assert.Equal(t, c2, p2)
+}
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	// generatorWindow is the window of the generator tables, scalar bytes are used as digits directly
+	generatorWindow = 8
+	// scalarBits is the number of bits in the serialized Fr
+	scalarBits = 256
+)
+
+// FixedBase holds multiples of a G1 or G2 base point, so multiplication by a scalar takes
+// one addition per window instead of a generic scalar multiplication. Entry j of window i is
+// (j + 1) * 2^(window * i) * base. The table is read only and safe for concurrent use.
+// Like G1Mul and G2Mul, the running time depends on the scalar, there is no constant time variant
+type FixedBase struct {
+	window int
+	g1     []G1
+	g2     []G2
+}
+
+// generatorTables are fixed base tables of the generators of the initialized curve built on first use
+type generatorTables struct {
+	g1Once, g2Once sync.Once
+	g1, g2         *FixedBase
+}
+
+var fixedBaseGenerators = new(generatorTables)
+
+// NewFixedBaseG1 precomputes multiples of the base. Window is in range [1, 16], the table
+// holds ceil(256 / window) * (2^window - 1) points
+func NewFixedBaseG1(base *G1, window int) (*FixedBase, error) {
+	if err := checkFixedBaseWindow(window); err != nil {
+		return nil, err
+	}
+
+	size := fixedBaseDigits(window)
+	f := &FixedBase{window: window, g1: make([]G1, fixedBaseWindows(window)*size)}
+
+	p := *base
+
+	for i := 0; i < len(f.g1); i += size {
+		f.g1[i] = p
+
+		for j := 1; j < size; j++ {
+			G1Add(&f.g1[i+j], &f.g1[i+j-1], &p)
+		}
+
+		// p = 2^window * p
+		G1Add(&p, &f.g1[i+size-1], &p)
+	}
+
+	for i := range f.g1 {
+		G1Normalize(&f.g1[i], &f.g1[i])
+	}
+
+	return f, nil
+}
+
+// NewFixedBaseG2 precomputes multiples of the base. Window is in range [1, 16], the table
+// holds ceil(256 / window) * (2^window - 1) points
+func NewFixedBaseG2(base *G2, window int) (*FixedBase, error) {
+	if err := checkFixedBaseWindow(window); err != nil {
+		return nil, err
+	}
+
+	size := fixedBaseDigits(window)
+	f := &FixedBase{window: window, g2: make([]G2, fixedBaseWindows(window)*size)}
+
+	p := *base
+
+	for i := 0; i < len(f.g2); i += size {
+		f.g2[i] = p
+
+		for j := 1; j < size; j++ {
+			G2Add(&f.g2[i+j], &f.g2[i+j-1], &p)
+		}
+
+		// p = 2^window * p
+		G2Add(&p, &f.g2[i+size-1], &p)
+	}
+
+	for i := range f.g2 {
+		G2Normalize(&f.g2[i], &f.g2[i])
+	}
+
+	return f, nil
+}
+
+// G1Mul -- out = x * base. Panics if the table is not built by NewFixedBaseG1
+func (f *FixedBase) G1Mul(out *G1, x *Fr) {
+	if f.g1 == nil {
+		panic("FixedBase.G1Mul: table of G2 base")
+	}
+
+	var res G1
+
+	f.forEachDigit(x, func(offset int) {
+		G1Add(&res, &res, &f.g1[offset])
+	})
+
+	*out = res
+}
+
+// G2Mul -- out = x * base. Panics if the table is not built by NewFixedBaseG2
+func (f *FixedBase) G2Mul(out *G2, x *Fr) {
+	if f.g2 == nil {
+		panic("FixedBase.G2Mul: table of G1 base")
+	}
+
+	var res G2
+
+	f.forEachDigit(x, func(offset int) {
+		G2Add(&res, &res, &f.g2[offset])
+	})
+
+	*out = res
+}
+
+// forEachDigit calls fn with the table offset of every non zero window digit of x
+func (f *FixedBase) forEachDigit(x *Fr, fn func(offset int)) {
+	raw := x.Serialize()
+	size := fixedBaseDigits(f.window)
+
+	for i := 0; i < fixedBaseWindows(f.window); i++ {
+		digit := 0
+
+		for bit := i * f.window; bit < (i+1)*f.window && bit < scalarBits; bit++ {
+			digit |= int(raw[bit/8]>>(bit%8)&1) << (bit - i*f.window)
+		}
+
+		if digit != 0 {
+			fn(i*size + digit - 1)
+		}
+	}
+}
+
+// G1MulGenerator -- out = x * G1Generator() using the precomputed table of the generator
+func G1MulGenerator(out *G1, x *Fr) {
+	t := fixedBaseGenerators
+
+	t.g1Once.Do(func() {
+		t.g1, _ = NewFixedBaseG1(ellipticCurveG1, generatorWindow)
+	})
+
+	t.g1.G1Mul(out, x)
+}
+
+// G2MulGenerator -- out = x * G2Generator() using the precomputed table of the generator
+func G2MulGenerator(out *G2, x *Fr) {
+	t := fixedBaseGenerators
+
+	t.g2Once.Do(func() {
+		t.g2, _ = NewFixedBaseG2(ellipticCurveG2, generatorWindow)
+	})
+
+	t.g2.G2Mul(out, x)
+}
+
+func checkFixedBaseWindow(window int) error {
+	if window < 1 || window > 16 {
+		return fmt.Errorf("fixed base window %d is out of range [1, 16]", window)
+	}
+
+	return nil
+}
+
+// fixedBaseWindows returns the number of windows covering the scalar
+func fixedBaseWindows(window int) int {
+	return (scalarBits + window - 1) / window
+}
+
+// fixedBaseDigits returns the number of non zero digits of the window
+func fixedBaseDigits(window int) int {
+	return 1<<window - 1
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FixedBase(t *testing.T) {
+	t.Parallel()
+
+	var (
+		x, zero           Fr
+		base1, exp1, out1 G1
+		base2, exp2, out2 G2
+	)
+
+	require.True(t, x.SetByCSPRNG())
+	G1Mul(&base1, ellipticCurveG1, &x)
+	G2Mul(&base2, ellipticCurveG2, &x)
+
+	for _, window := range []int{1, 4, 7, 8} {
+		fb1, err := NewFixedBaseG1(&base1, window)
+		require.NoError(t, err)
+
+		fb2, err := NewFixedBaseG2(&base2, window)
+		require.NoError(t, err)
+
+		for i := 0; i < 4; i++ {
+			require.True(t, x.SetByCSPRNG())
+
+			G1Mul(&exp1, &base1, &x)
+			fb1.G1Mul(&out1, &x)
+			assert.True(t, exp1.IsEqual(&out1), "window %d", window)
+
+			G2Mul(&exp2, &base2, &x)
+			fb2.G2Mul(&out2, &x)
+			assert.True(t, exp2.IsEqual(&out2), "window %d", window)
+		}
+
+		fb1.G1Mul(&out1, &zero)
+		assert.True(t, out1.IsZero())
+
+		fb2.G2Mul(&out2, &zero)
+		assert.True(t, out2.IsZero())
+
+		assert.Panics(t, func() { fb1.G2Mul(&out2, &x) })
+		assert.Panics(t, func() { fb2.G1Mul(&out1, &x) })
+	}
+
+	for _, window := range []int{0, -1, 17} {
+		_, err := NewFixedBaseG1(&base1, window)
+		assert.Error(t, err)
+
+		_, err = NewFixedBaseG2(&base2, window)
+		assert.Error(t, err)
+	}
+}
+
+func Test_MulGenerator(t *testing.T) {
+	t.Parallel()
+
+	var (
+		x          Fr
+		exp1, out1 G1
+		exp2, out2 G2
+	)
+
+	// -1 has every window digit set
+	x.SetInt64(-1)
+
+	for i := 0; i < 4; i++ {
+		G1Mul(&exp1, ellipticCurveG1, &x)
+		G1MulGenerator(&out1, &x)
+		assert.True(t, exp1.IsEqual(&out1))
+
+		G2Mul(&exp2, ellipticCurveG2, &x)
+		G2MulGenerator(&out2, &x)
+		assert.True(t, exp2.IsEqual(&out2))
+
+		require.True(t, x.SetByCSPRNG())
+	}
+
+	blsKey, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	G2Mul(&exp2, ellipticCurveG2, blsKey.p)
+	assert.True(t, exp2.IsEqual(blsKey.PublicKey().p))
+}
+
+func Benchmark_PublicKey(b *testing.B) {
+	blsKey, err := GenerateBlsKey()
+	require.NoError(b, err)
+
+	var out G2
+
+	b.Run("G2Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			G2Mul(&out, ellipticCurveG2, blsKey.p)
+		}
+	})
+
+	b.Run("G2MulGenerator", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			G2MulGenerator(&out, blsKey.p)
+		}
+	})
+}
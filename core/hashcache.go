@@ -0,0 +1,147 @@
+package core
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// HashCacheStats holds statistics of the message to point cache
+type HashCacheStats struct {
+	Hits   uint64
+	Misses uint64
+	// Size is the number of cached points
+	Size int
+	// Capacity is the maximal number of cached points, zero means the cache is disabled
+	Capacity int
+}
+
+// messageCache is a bounded LRU cache of normalized message points keyed by the hash of the domain
+// and the message. Once the cache is full, evicted entries are reused, so adding does not allocate
+type messageCache struct {
+	// capacity is read atomically, so the disabled cache costs no locking
+	capacity int64
+
+	mu      sync.Mutex
+	entries map[[32]byte]*list.Element
+	lru     *list.List
+	hits    uint64
+	misses  uint64
+}
+
+type messageCacheEntry struct {
+	key   [32]byte
+	point G1
+}
+
+var hashCache = newMessageCache()
+
+func newMessageCache() *messageCache {
+	return &messageCache{entries: make(map[[32]byte]*list.Element), lru: list.New()}
+}
+
+// SetHashCacheSize enables the cache of message points used by Sign, Verify and MarshalMessage
+// with the default HashToG1. Size is the maximal number of cached points, zero disables the cache.
+// Changing the size drops cached points and resets the statistics
+func SetHashCacheSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+
+	hashCache.mu.Lock()
+	defer hashCache.mu.Unlock()
+
+	hashCache.clear()
+	hashCache.hits, hashCache.misses = 0, 0
+	atomic.StoreInt64(&hashCache.capacity, int64(size))
+}
+
+// GetHashCacheStats returns statistics of the message to point cache
+func GetHashCacheStats() HashCacheStats {
+	hashCache.mu.Lock()
+	defer hashCache.mu.Unlock()
+
+	return HashCacheStats{
+		Hits:     hashCache.hits,
+		Misses:   hashCache.misses,
+		Size:     hashCache.lru.Len(),
+		Capacity: int(atomic.LoadInt64(&hashCache.capacity)),
+	}
+}
+
+func (c *messageCache) enabled() bool {
+	return atomic.LoadInt64(&c.capacity) > 0
+}
+
+// get sets out to the cached point of the key and reports whether it was found
+func (c *messageCache) get(key *[32]byte, out *G1) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[*key]
+	if !ok {
+		c.misses++
+
+		return false
+	}
+
+	c.hits++
+	c.lru.MoveToFront(e)
+	*out = e.Value.(*messageCacheEntry).point
+
+	return true
+}
+
+// add caches the point of the key evicting the least recently used one if the cache is full
+func (c *messageCache) add(key *[32]byte, point *G1) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	capacity := int(atomic.LoadInt64(&c.capacity))
+	if capacity == 0 {
+		return
+	}
+
+	if e, ok := c.entries[*key]; ok {
+		c.lru.MoveToFront(e)
+
+		return
+	}
+
+	if c.lru.Len() < capacity {
+		c.entries[*key] = c.lru.PushFront(&messageCacheEntry{key: *key, point: *point})
+
+		return
+	}
+
+	e := c.lru.Back()
+	entry := e.Value.(*messageCacheEntry)
+
+	delete(c.entries, entry.key)
+	entry.key, entry.point = *key, *point
+	c.entries[*key] = e
+	c.lru.MoveToFront(e)
+}
+
+// reset drops cached points, it is called when the curve changes
+func (c *messageCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clear()
+}
+
+func (c *messageCache) clear() {
+	c.entries = make(map[[32]byte]*list.Element)
+	c.lru.Init()
+}
+
+// cacheKey sets s.key to the hash of the domain and the message
+func (s *hashScratch) cacheKey(message []byte, domain []byte) {
+	s.h.Reset()
+	s.octet[0] = uint8(len(domain))
+	_, _ = s.h.Write(s.octet[:1])
+	_, _ = s.h.Write(domain)
+	_, _ = s.h.Write(message)
+	s.h.Sum(s.key[:0])
+}
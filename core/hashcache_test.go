@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_HashCache changes the global cache, so it must not run in parallel with other tests
+func Test_HashCache(t *testing.T) {
+	SetHashCacheSize(2)
+	defer SetHashCacheSize(0)
+
+	blsKey, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	publicKey := blsKey.PublicKey()
+	messages := [][]byte{
+		testGenRandomBytes(t, messageSize),
+		testGenRandomBytes(t, messageSize),
+		testGenRandomBytes(t, messageSize),
+	}
+
+	expected, err := HashToG107(messages[0])
+	require.NoError(t, err)
+
+	signature, err := blsKey.Sign(messages[0])
+	require.NoError(t, err)
+
+	assert.Equal(t, HashCacheStats{Misses: 1, Size: 1, Capacity: 2}, GetHashCacheStats())
+
+	// cached point is the same as the computed one
+	assert.True(t, signature.Verify(publicKey, messages[0]))
+
+	marshaled, err := MarshalMessage(messages[0])
+	require.NoError(t, err)
+	assert.Equal(t, G1ToBytes(expected), marshaled)
+
+	assert.Equal(t, HashCacheStats{Hits: 2, Misses: 1, Size: 1, Capacity: 2}, GetHashCacheStats())
+
+	// messages[2] evicts the least recently used messages[0], then messages[0] evicts messages[2]
+	for _, message := range [][]byte{messages[1], messages[2], messages[1], messages[0]} {
+		_, err := MarshalMessage(message)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, HashCacheStats{Hits: 3, Misses: 4, Size: 2, Capacity: 2}, GetHashCacheStats())
+
+	// the domain is a part of the key
+	originalDomain := GetDomain()
+
+	SetDomain([]byte("custom domain"))
+
+	marshaled, err = MarshalMessage(messages[0])
+	SetDomain(originalDomain)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, G1ToBytes(expected), marshaled)
+	assert.Equal(t, uint64(5), GetHashCacheStats().Misses)
+
+	SetHashCacheSize(0)
+
+	assert.True(t, signature.Verify(publicKey, messages[0]))
+	assert.Equal(t, HashCacheStats{}, GetHashCacheStats())
+}
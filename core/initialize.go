@@ -0,0 +1,245 @@
+package core
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ErrSelfTest is returned by Initialize and SelfTest when the library does not produce the known answers
+var ErrSelfTest = errors.New("self-test failed")
+
+// InitOptions configures Initialize
+type InitOptions struct {
+	// Curve points to either CurveSNARK1 or CurveFp254BNb, nil selects the default CurveSNARK1.
+	// It is a pointer because CurveFp254BNb is zero and must not be selected by the zero value
+	Curve *int
+	// SkipSelfTest disables the known-answer self-test
+	SkipSelfTest bool
+}
+
+// DefaultInitOptions returns options of the initialization performed on import
+func DefaultInitOptions() *InitOptions {
+	curve := CurveSNARK1
+
+	return &InitOptions{Curve: &curve}
+}
+
+// Initialize initializes the library with the given options (DefaultInitOptions if nil) and runs the
+// known-answer self-test. Unlike the implicit initialization on import it reports all failures as errors.
+// It must not be called concurrently with any other function of the package
+func Initialize(opts *InitOptions) error {
+	if opts == nil {
+		opts = DefaultInitOptions()
+	}
+
+	curve := CurveSNARK1
+	if opts.Curve != nil {
+		curve = *opts.Curve
+	}
+
+	if err := Init(curve); err != nil {
+		return fmt.Errorf("initialization of curve %d (mcl version %x): %w", curve, GetVersion(), err)
+	}
+
+	if opts.SkipSelfTest {
+		return nil
+	}
+
+	return SelfTest()
+}
+
+// selfTestVector holds known answers for two fixed private keys signing selfTestMessage
+// with the default domain and HashToG107
+type selfTestVector struct {
+	publicKey           string
+	signature           string
+	aggregatedPublicKey string
+	aggregatedSignature string
+}
+
+var (
+	selfTestPrivateKeys = []string{
+		"1234567890123456789012345678901234567890",
+		"9876543210987654321098765432109876543210",
+	}
+
+	selfTestMessage = []byte("gocrmcl known answer test")
+
+	selfTestVectors = map[int]selfTestVector{
+		CurveSNARK1: {
+			publicKey: "c21154191e80c1cb7b50b2e15c9347766bca2b87ec0fce7862ff7fc949218c096979040d36fb826116e26be10cbad8" +
+				"8c4993b15f7fea88ff451c9f0ef712e20c5bc5937736af6b6f97b11a93a9be990f9a367542d2553dba3e1a311d23731a05" +
+				"0d496615c74ae65beb1a0fc0825b0c4052a6004f57943908f16581f46d868023",
+			signature: "2ffef2e7906e0e3f17a59ca3b804c139ef406a74cf41edb19d3cffbf0b45e1205056b6ba7d2002d90cab1129be9a8dfc" +
+				"3d00c5fc4a255e659d787c0f99d6040f",
+			aggregatedPublicKey: "a5aaa0128bf08d8476d7564daebd6db492065290d6955aa9645088a4d7806c2899167fc9b46c8af1a95e13ecfc24acd0" +
+				"cc03ac460da336d9ce16dfe56bc2821899ff88a5bcc3a61cc6ef8bc92526d385ec803b2023139f73936d3c73d74fc60e" +
+				"cddd1a246f9fb4a37d8b5c1aa0c19ee5fdd5c3228e49287b6809a06e31efeb02",
+			aggregatedSignature: "fc2688f8820a472a89021a71ece236722473f145a54db8803150fcdb6d398b067e781919b089255f52db1062291d98de" +
+				"598e11cc5fe5229f1b10b74dc5444617",
+		},
+		CurveFp254BNb: {
+			publicKey: "724d5eadd33a0cbc2d2c8abfd90e5fcd960efe4691e36211b010b6b0fe6e2c065f5aaa3f857267bfa97ea84ec136ee0b" +
+				"ab90a3658e2fd0b6e38d77f499fecf0807fd9cd33373352e49bdbf4fff650d4738fb1bb51bfac58d3402aed766577b24" +
+				"6bfe680b9819a70c9ca7b2277f4e7618a064a6766383eab2fc77ab659f3acd02",
+			signature: "37fe5e8d68ac5330f9c101d64f61b1e34b275ce672e57afa816e77fbef1bf61fc3a1bc4f560798435b6b3f74dc6e2782" +
+				"505a6cfc2a8bb1010532f17c95edf21b",
+			aggregatedPublicKey: "aa7799b0460a82e68b7ab6aaa1eeda1affe3efdaaf6eec63251774f6a2743b1678fff02e4e6bae4e73a74a7415d4adb1" +
+				"67a492f7c843bb0c7324f9119da99f22b7e209990362760b5059c302e0ad2d381b5432212865400d011cf976d07a721d" +
+				"aada682567c80c3a4ad6fbe0bc69aca3492d6006e5f86612c4630f9339d61805",
+			aggregatedSignature: "4a54cc3d46e6fe8e9f35f3f12845d079be4fc97777c8567a3685ba1c5a4629204bd0b7e092f1e8da26dd3ff296c60559" +
+				"eaac7c694fdff69c4edbf980de68b624",
+		},
+	}
+)
+
+// SelfTest checks key generation, signing, verification, aggregation and serialization of the
+// initialized curve against known answers. It must not be called concurrently with any other
+// function of the package because it temporarily restores the default domain and HashToG1
+func SelfTest() error {
+	if initErr != nil {
+		return fmt.Errorf("%w: library is not initialized: %v", ErrSelfTest, initErr)
+	}
+
+	vector, ok := selfTestVectors[GetCurveType()]
+	if !ok {
+		return fmt.Errorf("%w: no known answers for curve %d", ErrSelfTest, GetCurveType())
+	}
+
+	savedDomain, savedHashToG1 := domain, customHashToG1
+
+	defer func() {
+		domain, customHashToG1 = savedDomain, savedHashToG1
+	}()
+
+	domain, _ = hex.DecodeString(defaultDomainHex)
+	SetHashToG1(nil)
+
+	if err := runSelfTest(&vector); err != nil {
+		return fmt.Errorf("%w: %v", ErrSelfTest, err)
+	}
+
+	return nil
+}
+
+func runSelfTest(vector *selfTestVector) error {
+	keys := make([]*PrivateKey, len(selfTestPrivateKeys))
+	signatures := make([]*Signature, len(selfTestPrivateKeys))
+
+	for i, s := range selfTestPrivateKeys {
+		fr := new(Fr)
+		if err := fr.SetString(s, 10); err != nil {
+			return err
+		}
+
+		keys[i] = &PrivateKey{p: fr}
+
+		signature, err := keys[i].Sign(selfTestMessage)
+		if err != nil {
+			return err
+		}
+
+		signatures[i] = signature
+	}
+
+	publicKeys := CollectPublicKeys(keys)
+	aggregatedPublicKey := AggregatePublicKeys(publicKeys)
+	aggregatedSignature := AggregateSignatures(signatures)
+
+	if err := checkKnownAnswer("public key", publicKeys[0].Marshal(), vector.publicKey); err != nil {
+		return err
+	}
+
+	if err := checkKnownAnswer("aggregated public key", aggregatedPublicKey.Marshal(), vector.aggregatedPublicKey); err != nil {
+		return err
+	}
+
+	for _, c := range []struct {
+		name      string
+		signature *Signature
+		expected  string
+	}{
+		{"signature", signatures[0], vector.signature},
+		{"aggregated signature", aggregatedSignature, vector.aggregatedSignature},
+	} {
+		raw, err := c.signature.Marshal()
+		if err != nil {
+			return err
+		}
+
+		if err := checkKnownAnswer(c.name, raw, c.expected); err != nil {
+			return err
+		}
+	}
+
+	if !signatures[0].Verify(publicKeys[0], selfTestMessage) ||
+		!aggregatedSignature.VerifyAggregated(publicKeys, selfTestMessage) {
+		return errors.New("valid signature is rejected")
+	}
+
+	if signatures[0].Verify(publicKeys[1], selfTestMessage) ||
+		aggregatedSignature.Verify(aggregatedPublicKey, selfTestMessage[1:]) {
+		return errors.New("invalid signature is accepted")
+	}
+
+	return selfTestSerialization()
+}
+
+// selfTestSerialization checks freshly generated key and its signature survive serialization round trip
+func selfTestSerialization() error {
+	key, err := GenerateBlsKey()
+	if err != nil {
+		return err
+	}
+
+	rawKey, err := key.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	key, err = UnmarshalPrivateKey(rawKey)
+	if err != nil {
+		return err
+	}
+
+	signature, err := key.Sign(selfTestMessage)
+	if err != nil {
+		return err
+	}
+
+	rawSignature, err := signature.Marshal()
+	if err != nil {
+		return err
+	}
+
+	signature, err = UnmarshalSignature(rawSignature)
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := UnmarshalPublicKey(key.PublicKey().Marshal())
+	if err != nil {
+		return err
+	}
+
+	if !signature.Verify(publicKey, selfTestMessage) {
+		return errors.New("signature of generated key is rejected after serialization")
+	}
+
+	return nil
+}
+
+func checkKnownAnswer(name string, actual []byte, expected string) error {
+	expectedBytes, err := hex.DecodeString(expected)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(actual, expectedBytes) {
+		return fmt.Errorf("%s mismatch: expected %s, got %x", name, expected, actual)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_Initialize switches the global curve, so it must not run in parallel with other tests
+func Test_Initialize(t *testing.T) {
+	defer func() {
+		require.NoError(t, Initialize(nil))
+	}()
+
+	require.NoError(t, Initialize(nil))
+	assert.Equal(t, CurveSNARK1, GetCurveType())
+	assert.NotZero(t, GetVersion())
+
+	curve := CurveFp254BNb
+
+	require.NoError(t, Initialize(&InitOptions{Curve: &curve}))
+	assert.Equal(t, CurveFp254BNb, GetCurveType())
+
+	// generator tables follow the initialized curve
+	var (
+		x        Fr
+		exp, out G2
+	)
+
+	require.True(t, x.SetByCSPRNG())
+	G2Mul(&exp, ellipticCurveG2, &x)
+	G2MulGenerator(&out, &x)
+	assert.True(t, exp.IsEqual(&out))
+
+	// unsupported curve does not break the initialized one
+	curve = BLS12_381
+
+	assert.ErrorIs(t, Initialize(&InitOptions{Curve: &curve}), errUnsupportedCurve)
+	assert.Equal(t, CurveFp254BNb, GetCurveType())
+	assert.NoError(t, SelfTest())
+
+	// zero value options select the default curve, not CurveFp254BNb which is zero
+	require.NoError(t, Initialize(&InitOptions{}))
+	assert.Equal(t, CurveSNARK1, GetCurveType())
+}
+
+// Test_SelfTestCustomDomain changes the global domain, so it must not run in parallel with other tests
+func Test_SelfTestCustomDomain(t *testing.T) {
+	customDomain := []byte("custom domain")
+	originalDomain := GetDomain()
+
+	SetDomain(customDomain)
+	defer SetDomain(originalDomain)
+
+	require.NoError(t, SelfTest())
+	assert.Equal(t, customDomain, GetDomain())
+}
+
+// Test_SelfTestInitError replaces the global initialization error, so it must not run in parallel with other tests
+func Test_SelfTestInitError(t *testing.T) {
+	savedInitErr := initErr
+	defer func() {
+		initErr = savedInitErr
+	}()
+
+	initErr = errors.New("mcl initialization failure")
+
+	err := SelfTest()
+	assert.ErrorIs(t, err, ErrSelfTest)
+	assert.ErrorContains(t, err, "mcl initialization failure")
+
+	// Initialize does not rely on the failed initialization
+	require.NoError(t, Initialize(nil))
+	assert.NoError(t, initErr)
+}
+
+func Test_SelfTestMismatch(t *testing.T) {
+	t.Parallel()
+
+	vector := selfTestVectors[CurveSNARK1]
+	vector.aggregatedSignature = vector.signature
+
+	assert.ErrorContains(t, runSelfTest(&vector), "aggregated signature mismatch")
+}
@@ -0,0 +1,483 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// gnark-crypto BN254 encoding keeps metadata in the two most significant bits of the first byte
+const (
+	gnarkMask               byte = 0b11 << 6
+	gnarkUncompressed       byte = 0b00 << 6
+	gnarkCompressedSmallest byte = 0b10 << 6
+	gnarkCompressedLargest  byte = 0b11 << 6
+	gnarkInfinity           byte = 0b01 << 6
+)
+
+// arkworks BN254 encoding keeps flags in the two most significant bits of the last byte
+const (
+	arkworksYIsNegative     byte = 1 << 7
+	arkworksPointAtInfinity byte = 1 << 6
+	arkworksFlagsMask            = arkworksYIsNegative | arkworksPointAtInfinity
+)
+
+var errInvalidInteropInfinity = errors.New("invalid encoding of the point at infinity")
+
+// G1ToGnark encodes point as gnark-crypto 64 bytes uncompressed form.
+// Like gnark-crypto RawBytes the point at infinity is all zero bytes without flags
+func G1ToGnark(p *G1) []byte {
+	return G1ToEVM(p)
+}
+
+// G1ToGnarkCompressed encodes point as gnark-crypto 32 bytes compressed form
+func G1ToGnarkCompressed(p *G1) []byte {
+	res := make([]byte, 32)
+	if p.IsZero() {
+		res[0] = gnarkInfinity
+
+		return res
+	}
+
+	var np G1
+
+	G1Normalize(&np, p)
+
+	copy(res, fpToBigEndian(&np.X))
+
+	if np.Y.IsNegative() {
+		res[0] |= gnarkCompressedLargest
+	} else {
+		res[0] |= gnarkCompressedSmallest
+	}
+
+	return res
+}
+
+// G1FromGnark decodes point from gnark-crypto compressed (32 bytes) or uncompressed (64 bytes) form
+func G1FromGnark(raw []byte) (*G1, error) {
+	if len(raw) != 32 && len(raw) != 64 {
+		return nil, fmt.Errorf("expect length 32 or 64 but got %d", len(raw))
+	}
+
+	buf, flags := splitGnarkFlags(raw)
+	if err := checkGnarkFlags(flags, len(raw) == 32); err != nil {
+		return nil, err
+	}
+
+	g1 := new(G1)
+
+	// compressed infinity has the flag, uncompressed one is all zero bytes
+	if flags == gnarkInfinity || (flags == gnarkUncompressed && isAllZero(buf)) {
+		if !isAllZero(buf) {
+			return nil, errInvalidInteropInfinity
+		}
+
+		return g1, nil
+	}
+
+	if len(raw) == 64 {
+		for i, x := range []*Fp{&g1.X, &g1.Y} {
+			if err := fpFromBigEndian(x, buf[i*32:(i+1)*32]); err != nil {
+				return nil, err
+			}
+		}
+
+		g1.Z.SetInt64(1)
+	} else {
+		if err := fpFromBigEndian(&g1.X, buf); err != nil {
+			return nil, err
+		}
+
+		if !g1RecoverY(g1) {
+			return nil, ErrNotOnCurve
+		}
+
+		if g1.Y.IsNegative() != (flags == gnarkCompressedLargest) {
+			FpNeg(&g1.Y, &g1.Y)
+		}
+	}
+
+	if err := ValidateG1(g1); err != nil {
+		return nil, err
+	}
+
+	return g1, nil
+}
+
+// G2ToGnark encodes point as gnark-crypto 128 bytes uncompressed form.
+// Like gnark-crypto RawBytes the point at infinity is all zero bytes without flags
+func G2ToGnark(p *G2) []byte {
+	return G2ToEVM(p)
+}
+
+// G2ToGnarkCompressed encodes point as gnark-crypto 64 bytes compressed form
+func G2ToGnarkCompressed(p *G2) []byte {
+	res := make([]byte, 64)
+	if p.IsZero() {
+		res[0] = gnarkInfinity
+
+		return res
+	}
+
+	var np G2
+
+	G2Normalize(&np, p)
+
+	copy(res, fpToBigEndian(&np.X.D[1]))
+	copy(res[32:], fpToBigEndian(&np.X.D[0]))
+
+	if fp2IsNegative(&np.Y) {
+		res[0] |= gnarkCompressedLargest
+	} else {
+		res[0] |= gnarkCompressedSmallest
+	}
+
+	return res
+}
+
+// G2FromGnark decodes point from gnark-crypto compressed (64 bytes) or uncompressed (128 bytes) form
+func G2FromGnark(raw []byte) (*G2, error) {
+	if len(raw) != 64 && len(raw) != 128 {
+		return nil, fmt.Errorf("expect length 64 or 128 but got %d", len(raw))
+	}
+
+	buf, flags := splitGnarkFlags(raw)
+	if err := checkGnarkFlags(flags, len(raw) == 64); err != nil {
+		return nil, err
+	}
+
+	g2 := new(G2)
+
+	// compressed infinity has the flag, uncompressed one is all zero bytes
+	if flags == gnarkInfinity || (flags == gnarkUncompressed && isAllZero(buf)) {
+		if !isAllZero(buf) {
+			return nil, errInvalidInteropInfinity
+		}
+
+		return g2, nil
+	}
+
+	if err := fpFromBigEndian(&g2.X.D[1], buf[:32]); err != nil {
+		return nil, err
+	}
+
+	if err := fpFromBigEndian(&g2.X.D[0], buf[32:64]); err != nil {
+		return nil, err
+	}
+
+	if len(raw) == 128 {
+		if err := fpFromBigEndian(&g2.Y.D[1], buf[64:96]); err != nil {
+			return nil, err
+		}
+
+		if err := fpFromBigEndian(&g2.Y.D[0], buf[96:]); err != nil {
+			return nil, err
+		}
+
+		g2.Z.D[0].SetInt64(1)
+	} else {
+		if !g2RecoverY(g2) {
+			return nil, ErrNotOnCurve
+		}
+
+		if fp2IsNegative(&g2.Y) != (flags == gnarkCompressedLargest) {
+			Fp2Neg(&g2.Y, &g2.Y)
+		}
+	}
+
+	if err := ValidateG2(g2); err != nil {
+		return nil, err
+	}
+
+	return g2, nil
+}
+
+// FrToGnark encodes scalar as gnark-crypto 32 bytes big-endian form
+func FrToGnark(x *Fr) []byte {
+	return reverseBytes(x.Serialize())
+}
+
+// FrFromGnark decodes scalar from gnark-crypto 32 bytes big-endian form
+func FrFromGnark(raw []byte) (*Fr, error) {
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("expect length 32 but got %d", len(raw))
+	}
+
+	return frFromLittleEndian(reverseBytes(raw))
+}
+
+// G1ToArkworks encodes point as arkworks 64 bytes uncompressed form
+func G1ToArkworks(p *G1) []byte {
+	res := make([]byte, 64)
+	if p.IsZero() {
+		res[63] = arkworksPointAtInfinity
+
+		return res
+	}
+
+	var np G1
+
+	G1Normalize(&np, p)
+
+	copy(res, np.X.Serialize())
+	copy(res[32:], np.Y.Serialize())
+
+	if np.Y.IsNegative() {
+		res[63] |= arkworksYIsNegative
+	}
+
+	return res
+}
+
+// G1ToArkworksCompressed encodes point as arkworks 32 bytes compressed form
+func G1ToArkworksCompressed(p *G1) []byte {
+	res := make([]byte, 32)
+	if p.IsZero() {
+		res[31] = arkworksPointAtInfinity
+
+		return res
+	}
+
+	var np G1
+
+	G1Normalize(&np, p)
+
+	copy(res, np.X.Serialize())
+
+	if np.Y.IsNegative() {
+		res[31] |= arkworksYIsNegative
+	}
+
+	return res
+}
+
+// G1FromArkworks decodes point from arkworks compressed (32 bytes) or uncompressed (64 bytes) form
+func G1FromArkworks(raw []byte) (*G1, error) {
+	if len(raw) != 32 && len(raw) != 64 {
+		return nil, fmt.Errorf("expect length 32 or 64 but got %d", len(raw))
+	}
+
+	buf, flags := splitArkworksFlags(raw)
+	if flags == arkworksFlagsMask {
+		return nil, fmt.Errorf("invalid arkworks flags %08b", flags)
+	}
+
+	g1 := new(G1)
+
+	if flags == arkworksPointAtInfinity {
+		if !isAllZero(buf) {
+			return nil, errInvalidInteropInfinity
+		}
+
+		return g1, nil
+	}
+
+	if err := fpFromLittleEndian(&g1.X, buf[:32]); err != nil {
+		return nil, err
+	}
+
+	if len(raw) == 64 {
+		if err := fpFromLittleEndian(&g1.Y, buf[32:]); err != nil {
+			return nil, err
+		}
+
+		g1.Z.SetInt64(1)
+	} else {
+		if !g1RecoverY(g1) {
+			return nil, ErrNotOnCurve
+		}
+
+		if g1.Y.IsNegative() != (flags == arkworksYIsNegative) {
+			FpNeg(&g1.Y, &g1.Y)
+		}
+	}
+
+	if err := ValidateG1(g1); err != nil {
+		return nil, err
+	}
+
+	return g1, nil
+}
+
+// G2ToArkworks encodes point as arkworks 128 bytes uncompressed form
+func G2ToArkworks(p *G2) []byte {
+	res := make([]byte, 128)
+	if p.IsZero() {
+		res[127] = arkworksPointAtInfinity
+
+		return res
+	}
+
+	var np G2
+
+	G2Normalize(&np, p)
+
+	copy(res, np.X.D[0].Serialize())
+	copy(res[32:], np.X.D[1].Serialize())
+	copy(res[64:], np.Y.D[0].Serialize())
+	copy(res[96:], np.Y.D[1].Serialize())
+
+	if fp2IsNegative(&np.Y) {
+		res[127] |= arkworksYIsNegative
+	}
+
+	return res
+}
+
+// G2ToArkworksCompressed encodes point as arkworks 64 bytes compressed form
+func G2ToArkworksCompressed(p *G2) []byte {
+	res := make([]byte, 64)
+	if p.IsZero() {
+		res[63] = arkworksPointAtInfinity
+
+		return res
+	}
+
+	var np G2
+
+	G2Normalize(&np, p)
+
+	copy(res, np.X.D[0].Serialize())
+	copy(res[32:], np.X.D[1].Serialize())
+
+	if fp2IsNegative(&np.Y) {
+		res[63] |= arkworksYIsNegative
+	}
+
+	return res
+}
+
+// G2FromArkworks decodes point from arkworks compressed (64 bytes) or uncompressed (128 bytes) form
+func G2FromArkworks(raw []byte) (*G2, error) {
+	if len(raw) != 64 && len(raw) != 128 {
+		return nil, fmt.Errorf("expect length 64 or 128 but got %d", len(raw))
+	}
+
+	buf, flags := splitArkworksFlags(raw)
+	if flags == arkworksFlagsMask {
+		return nil, fmt.Errorf("invalid arkworks flags %08b", flags)
+	}
+
+	g2 := new(G2)
+
+	if flags == arkworksPointAtInfinity {
+		if !isAllZero(buf) {
+			return nil, errInvalidInteropInfinity
+		}
+
+		return g2, nil
+	}
+
+	if err := fpFromLittleEndian(&g2.X.D[0], buf[:32]); err != nil {
+		return nil, err
+	}
+
+	if err := fpFromLittleEndian(&g2.X.D[1], buf[32:64]); err != nil {
+		return nil, err
+	}
+
+	if len(raw) == 128 {
+		if err := fpFromLittleEndian(&g2.Y.D[0], buf[64:96]); err != nil {
+			return nil, err
+		}
+
+		if err := fpFromLittleEndian(&g2.Y.D[1], buf[96:]); err != nil {
+			return nil, err
+		}
+
+		g2.Z.D[0].SetInt64(1)
+	} else {
+		if !g2RecoverY(g2) {
+			return nil, ErrNotOnCurve
+		}
+
+		if fp2IsNegative(&g2.Y) != (flags == arkworksYIsNegative) {
+			Fp2Neg(&g2.Y, &g2.Y)
+		}
+	}
+
+	if err := ValidateG2(g2); err != nil {
+		return nil, err
+	}
+
+	return g2, nil
+}
+
+// FrToArkworks encodes scalar as arkworks 32 bytes little-endian form
+func FrToArkworks(x *Fr) []byte {
+	return x.Serialize()
+}
+
+// FrFromArkworks decodes scalar from arkworks 32 bytes little-endian form
+func FrFromArkworks(raw []byte) (*Fr, error) {
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("expect length 32 but got %d", len(raw))
+	}
+
+	return frFromLittleEndian(raw)
+}
+
+// splitGnarkFlags returns copy of the encoding without flags and the flags from the first byte
+func splitGnarkFlags(raw []byte) ([]byte, byte) {
+	buf := make([]byte, len(raw))
+	copy(buf, raw)
+
+	flags := buf[0] & gnarkMask
+	buf[0] &^= gnarkMask
+
+	return buf, flags
+}
+
+// checkGnarkFlags checks that flags match compressed or uncompressed form.
+// The infinity flag is used only by the compressed form
+func checkGnarkFlags(flags byte, compressed bool) error {
+	switch flags {
+	case gnarkInfinity:
+		if compressed {
+			return nil
+		}
+	case gnarkUncompressed:
+		if !compressed {
+			return nil
+		}
+	case gnarkCompressedSmallest, gnarkCompressedLargest:
+		if compressed {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid gnark flags %08b", flags)
+}
+
+// splitArkworksFlags returns copy of the encoding without flags and the flags from the last byte
+func splitArkworksFlags(raw []byte) ([]byte, byte) {
+	buf := make([]byte, len(raw))
+	copy(buf, raw)
+
+	flags := buf[len(buf)-1] & arkworksFlagsMask
+	buf[len(buf)-1] &^= arkworksFlagsMask
+
+	return buf, flags
+}
+
+// fp2IsNegative returns true if imaginary part is "negative" or
+// if real part is "negative" when imaginary part is zero
+func fp2IsNegative(x *Fp2) bool {
+	if x.D[1].IsZero() {
+		return x.D[0].IsNegative()
+	}
+
+	return x.D[1].IsNegative()
+}
+
+// frFromLittleEndian decodes scalar from 32 bytes little-endian form.
+// Values which are not less than the curve order are rejected
+func frFromLittleEndian(raw []byte) (*Fr, error) {
+	x := new(Fr)
+
+	if err := x.Deserialize(raw); err != nil {
+		return nil, fmt.Errorf("%w: %x", ErrNonCanonical, raw)
+	}
+
+	return x, nil
+}
@@ -0,0 +1,218 @@
+package core
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// interopVector holds reference gnark-crypto and arkworks encodings of a single value. gnark-crypto
+// encodings are generated by testdata/interopgen, arkworks encodings are written from the arkworks
+// serialization format until interopgen is run with the output of testdata/interopgen/arkworks.
+// Empty coordinates denote the point at infinity
+type interopVector struct {
+	Name               string `json:"name"`
+	X                  string `json:"x"`
+	Y                  string `json:"y"`
+	Value              string `json:"value"`
+	Gnark              string `json:"gnark"`
+	GnarkCompressed    string `json:"gnarkCompressed"`
+	Arkworks           string `json:"arkworks"`
+	ArkworksCompressed string `json:"arkworksCompressed"`
+}
+
+type interopVectors struct {
+	G1 []interopVector `json:"g1"`
+	G2 []interopVector `json:"g2"`
+	Fr []interopVector `json:"fr"`
+}
+
+func Test_InteropG1Vectors(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range testLoadInteropVectors(t).G1 {
+		expected := new(G1)
+		if v.X != "" {
+			require.NoError(t, expected.SetString("1 "+v.X+" "+v.Y, 10), v.Name)
+		}
+
+		assert.Equal(t, v.Gnark, hex.EncodeToString(G1ToGnark(expected)), v.Name)
+		assert.Equal(t, v.GnarkCompressed, hex.EncodeToString(G1ToGnarkCompressed(expected)), v.Name)
+		assert.Equal(t, v.Arkworks, hex.EncodeToString(G1ToArkworks(expected)), v.Name)
+		assert.Equal(t, v.ArkworksCompressed, hex.EncodeToString(G1ToArkworksCompressed(expected)), v.Name)
+
+		for _, raw := range []string{v.Gnark, v.GnarkCompressed} {
+			g1, err := G1FromGnark(testDecodeHex(t, raw))
+			require.NoError(t, err, v.Name)
+			assert.True(t, expected.IsEqual(g1), v.Name)
+		}
+
+		for _, raw := range []string{v.Arkworks, v.ArkworksCompressed} {
+			g1, err := G1FromArkworks(testDecodeHex(t, raw))
+			require.NoError(t, err, v.Name)
+			assert.True(t, expected.IsEqual(g1), v.Name)
+		}
+	}
+}
+
+func Test_InteropG2Vectors(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range testLoadInteropVectors(t).G2 {
+		expected := new(G2)
+		if v.X != "" {
+			require.NoError(t, expected.SetString("1 "+v.X+" "+v.Y, 10), v.Name)
+		}
+
+		assert.Equal(t, v.Gnark, hex.EncodeToString(G2ToGnark(expected)), v.Name)
+		assert.Equal(t, v.GnarkCompressed, hex.EncodeToString(G2ToGnarkCompressed(expected)), v.Name)
+		assert.Equal(t, v.Arkworks, hex.EncodeToString(G2ToArkworks(expected)), v.Name)
+		assert.Equal(t, v.ArkworksCompressed, hex.EncodeToString(G2ToArkworksCompressed(expected)), v.Name)
+
+		for _, raw := range []string{v.Gnark, v.GnarkCompressed} {
+			g2, err := G2FromGnark(testDecodeHex(t, raw))
+			require.NoError(t, err, v.Name)
+			assert.True(t, expected.IsEqual(g2), v.Name)
+		}
+
+		for _, raw := range []string{v.Arkworks, v.ArkworksCompressed} {
+			g2, err := G2FromArkworks(testDecodeHex(t, raw))
+			require.NoError(t, err, v.Name)
+			assert.True(t, expected.IsEqual(g2), v.Name)
+		}
+	}
+}
+
+func Test_InteropFrVectors(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range testLoadInteropVectors(t).Fr {
+		expected := new(Fr)
+		require.NoError(t, expected.SetString(v.Value, 10))
+
+		assert.Equal(t, v.Gnark, hex.EncodeToString(FrToGnark(expected)), v.Value)
+		assert.Equal(t, v.Arkworks, hex.EncodeToString(FrToArkworks(expected)), v.Value)
+
+		fr, err := FrFromGnark(testDecodeHex(t, v.Gnark))
+		require.NoError(t, err)
+		assert.True(t, expected.IsEqual(fr), v.Value)
+
+		fr, err = FrFromArkworks(testDecodeHex(t, v.Arkworks))
+		require.NoError(t, err)
+		assert.True(t, expected.IsEqual(fr), v.Value)
+	}
+}
+
+func Test_InteropGnarkInfinity(t *testing.T) {
+	t.Parallel()
+
+	// gnark-crypto v0.19.2 RawBytes and Bytes of the zero bn254.G1Affine and bn254.G2Affine
+	g1Raw, g1Compressed := make([]byte, 64), make([]byte, 32)
+	g2Raw, g2Compressed := make([]byte, 128), make([]byte, 64)
+	g1Compressed[0], g2Compressed[0] = 0x40, 0x40
+
+	for _, raw := range [][]byte{g1Raw, g1Compressed} {
+		g1, err := G1FromGnark(raw)
+		require.NoError(t, err)
+		assert.True(t, g1.IsZero())
+	}
+
+	for _, raw := range [][]byte{g2Raw, g2Compressed} {
+		g2, err := G2FromGnark(raw)
+		require.NoError(t, err)
+		assert.True(t, g2.IsZero())
+	}
+
+	assert.Equal(t, g1Raw, G1ToGnark(new(G1)))
+	assert.Equal(t, g1Compressed, G1ToGnarkCompressed(new(G1)))
+	assert.Equal(t, g2Raw, G2ToGnark(new(G2)))
+	assert.Equal(t, g2Compressed, G2ToGnarkCompressed(new(G2)))
+
+	// the infinity flag belongs to the compressed form only
+	g1Raw[0], g2Raw[0] = 0x40, 0x40
+
+	_, err := G1FromGnark(g1Raw)
+	assert.Error(t, err)
+
+	_, err = G2FromGnark(g2Raw)
+	assert.Error(t, err)
+}
+
+func Test_InteropInvalidEncodings(t *testing.T) {
+	t.Parallel()
+
+	gen := testDecodeHex(t, "8000000000000000000000000000000000000000000000000000000000000001")
+
+	// compressed flag on uncompressed length
+	_, err := G1FromGnark(append(gen, make([]byte, 32)...))
+	assert.Error(t, err)
+
+	// uncompressed flag on compressed length
+	gen[0] = gnarkUncompressed
+
+	_, err = G1FromGnark(gen)
+	assert.Error(t, err)
+
+	// infinity with non zero payload
+	gen[0] = gnarkInfinity
+
+	_, err = G1FromGnark(gen)
+	assert.Error(t, err)
+
+	raw := make([]byte, 32)
+	raw[31] = arkworksFlagsMask
+
+	_, err = G1FromArkworks(raw)
+	assert.Error(t, err)
+
+	// x = 0 is not on the curve
+	_, err = G1FromArkworks(make([]byte, 32))
+	assert.ErrorIs(t, err, ErrNotOnCurve)
+
+	// (1, 1) is not on the curve
+	raw = make([]byte, 64)
+	raw[0], raw[32] = 1, 1
+
+	_, err = G1FromArkworks(raw)
+	assert.ErrorIs(t, err, ErrNotOnCurve)
+
+	// curve order is not canonical scalar
+	order := testDecodeHex(t, "30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001")
+
+	_, err = FrFromGnark(order)
+	assert.ErrorIs(t, err, ErrNonCanonical)
+
+	_, err = FrFromArkworks(reverseBytes(order))
+	assert.ErrorIs(t, err, ErrNonCanonical)
+
+	_, err = G2FromGnark(make([]byte, 32))
+	assert.Error(t, err)
+
+	_, err = G2FromArkworks(make([]byte, 127))
+	assert.Error(t, err)
+}
+
+func testLoadInteropVectors(t *testing.T) *interopVectors {
+	t.Helper()
+
+	raw, err := os.ReadFile("testdata/interop_vectors.json")
+	require.NoError(t, err)
+
+	vectors := new(interopVectors)
+	require.NoError(t, json.Unmarshal(raw, vectors))
+
+	return vectors
+}
+
+func testDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	raw, err := hex.DecodeString(s)
+	require.NoError(t, err)
+
+	return raw
+}
@@ -1,3 +1,5 @@
+//go:build !purego
+
 package core
 
 /*
@@ -7,6 +9,97 @@ package core
 #cgo linux,amd64 LDFLAGS:-L${SRCDIR}/../mclherumi/lib/linux/amd64
 #cgo darwin,arm64 LDFLAGS:-L${SRCDIR}/../mclherumi/lib/darwin/arm64
 #include <mcl/bn.h>
+
+// slice operations run in a single cgo call, out may alias the inputs
+
+static void goFrAddVec(mclBnFr *z, const mclBnFr *x, const mclBnFr *y, mclSize n) {
+	for (mclSize i = 0; i < n; i++) mclBnFr_add(&z[i], &x[i], &y[i]);
+}
+
+static void goFrSubVec(mclBnFr *z, const mclBnFr *x, const mclBnFr *y, mclSize n) {
+	for (mclSize i = 0; i < n; i++) mclBnFr_sub(&z[i], &x[i], &y[i]);
+}
+
+static void goFrMulVec(mclBnFr *z, const mclBnFr *x, const mclBnFr *y, mclSize n) {
+	for (mclSize i = 0; i < n; i++) mclBnFr_mul(&z[i], &x[i], &y[i]);
+}
+
+// GO_BATCH_INV defines go<T>BatchInv setting y[i] = 1 / x[i] with a single inversion
+// (Montgomery's trick). Zero elements are skipped and stay zero, tmp holds n elements
+#define GO_BATCH_INV(T) \
+static void go##T##BatchInv(T *y, const T *x, T *tmp, mclSize n) { \
+	mclSize i, first = n; \
+	T acc, t; \
+	for (i = 0; i < n; i++) { \
+		if (T##_isZero(&x[i])) continue; \
+		if (first == n) { first = i; acc = x[i]; continue; } \
+		tmp[i] = acc; \
+		T##_mul(&acc, &acc, &x[i]); \
+	} \
+	if (first < n) T##_inv(&acc, &acc); \
+	for (i = n; i-- > 0;) { \
+		if (T##_isZero(&x[i])) { T##_clear(&y[i]); continue; } \
+		if (i == first) { y[i] = acc; continue; } \
+		t = x[i]; \
+		T##_mul(&y[i], &acc, &tmp[i]); \
+		T##_mul(&acc, &acc, &t); \
+	} \
+}
+
+GO_BATCH_INV(mclBnFr)
+GO_BATCH_INV(mclBnFp)
+GO_BATCH_INV(mclBnFp2)
+
+// goG1NormalizeVec normalizes Jacobian points with a single inversion, zInv and tmp hold n elements
+static void goG1NormalizeVec(mclBnG1 *y, const mclBnG1 *x, mclBnFp *zInv, mclBnFp *tmp, mclSize n) {
+	mclBnFp t;
+	for (mclSize i = 0; i < n; i++) zInv[i] = x[i].z;
+	gomclBnFpBatchInv(zInv, zInv, tmp, n);
+	for (mclSize i = 0; i < n; i++) {
+		if (mclBnG1_isZero(&x[i])) { y[i] = x[i]; continue; }
+		mclBnFp_sqr(&t, &zInv[i]);
+		mclBnFp_mul(&y[i].x, &x[i].x, &t);
+		mclBnFp_mul(&t, &t, &zInv[i]);
+		mclBnFp_mul(&y[i].y, &x[i].y, &t);
+		mclBnFp_setInt(&y[i].z, 1);
+	}
+}
+
+// goG2NormalizeVec normalizes Jacobian points with a single inversion, zInv and tmp hold n elements
+static void goG2NormalizeVec(mclBnG2 *y, const mclBnG2 *x, mclBnFp2 *zInv, mclBnFp2 *tmp, mclSize n) {
+	mclBnFp2 t;
+	for (mclSize i = 0; i < n; i++) zInv[i] = x[i].z;
+	gomclBnFp2BatchInv(zInv, zInv, tmp, n);
+	for (mclSize i = 0; i < n; i++) {
+		if (mclBnG2_isZero(&x[i])) { y[i] = x[i]; continue; }
+		mclBnFp2_sqr(&t, &zInv[i]);
+		mclBnFp2_mul(&y[i].x, &x[i].x, &t);
+		mclBnFp2_mul(&t, &t, &zInv[i]);
+		mclBnFp2_mul(&y[i].y, &x[i].y, &t);
+		mclBnFp_setInt(&y[i].z.d[0], 1);
+		mclBnFp_clear(&y[i].z.d[1]);
+	}
+}
+
+// goBlsVerify checks e(sig, Q) e(-H, pub) == 1 in a single cgo call, Q is the G2 generator precomputed
+// in coef. H is h if it is not NULL, otherwise it is hashed from 96 uniform bytes of expand_message_xmd.
+// Returns 1 if the signature is valid, 0 if it is not and -1 if the message can not be mapped
+static int goBlsVerify(const mclBnG1 *sig, const mclBnG2 *pub, const mclBnG1 *h, const unsigned char *uniform, const uint64_t *coef) {
+	mclBnG1 p, q;
+	mclBnFp u;
+	mclBnGT e;
+	if (h == NULL) {
+		if (mclBnFp_setBigEndianMod(&u, uniform, 48) != 0 || mclBnFp_mapToG1(&p, &u) != 0) return -1;
+		if (mclBnFp_setBigEndianMod(&u, uniform + 48, 48) != 0 || mclBnFp_mapToG1(&q, &u) != 0) return -1;
+		mclBnG1_add(&p, &p, &q);
+	} else {
+		p = *h;
+	}
+	mclBnG1_neg(&p, &p);
+	mclBn_precomputedMillerLoop2mixed(&e, &p, pub, sig, coef);
+	mclBn_finalExp(&e, &e);
+	return mclBnGT_isOne(&e);
+}
 */
 import "C"
 import (
@@ -29,6 +122,16 @@ const CurveFp382_2 = C.mclBn_CurveFp382_2
 // BLS12_381 --
 const BLS12_381 = C.MCL_BLS12_381
 
+// GetVersion -- return 0xABC which means mcl version A.BC
+func GetVersion() int {
+	return int(C.mclBn_getVersion())
+}
+
+// GetCurveType -- return the initialized curve
+func GetCurveType() int {
+	return int(C.mclBn_getCurveType())
+}
+
 func InitCurve(curve int) error {
 	if code := C.mclBn_init(C.int(curve), C.MCLBN_COMPILED_TIME_VAR); code != 0 {
 		return fmt.Errorf("mclBn_init curve %d error: %d", curve, code)
@@ -337,6 +440,43 @@ func FrMul(out *Fr, x *Fr, y *Fr) {
 	C.mclBnFr_mul(out.getPointer(), x.getPointer(), y.getPointer())
 }
 
+// FrAddVec -- out[i] = xVec[i] + yVec[i] in a single cgo call
+func FrAddVec(out []Fr, xVec []Fr, yVec []Fr) {
+	n := checkVecSize(len(out), len(xVec), len(yVec))
+	if n == 0 {
+		return
+	}
+	C.goFrAddVec(out[0].getPointer(), xVec[0].getPointer(), yVec[0].getPointer(), (C.size_t)(n))
+}
+
+// FrSubVec -- out[i] = xVec[i] - yVec[i] in a single cgo call
+func FrSubVec(out []Fr, xVec []Fr, yVec []Fr) {
+	n := checkVecSize(len(out), len(xVec), len(yVec))
+	if n == 0 {
+		return
+	}
+	C.goFrSubVec(out[0].getPointer(), xVec[0].getPointer(), yVec[0].getPointer(), (C.size_t)(n))
+}
+
+// FrMulVec -- out[i] = xVec[i] * yVec[i] in a single cgo call
+func FrMulVec(out []Fr, xVec []Fr, yVec []Fr) {
+	n := checkVecSize(len(out), len(xVec), len(yVec))
+	if n == 0 {
+		return
+	}
+	C.goFrMulVec(out[0].getPointer(), xVec[0].getPointer(), yVec[0].getPointer(), (C.size_t)(n))
+}
+
+// FrBatchInv -- out[i] = 1 / xVec[i] with a single inversion, zero elements stay zero
+func FrBatchInv(out []Fr, xVec []Fr) {
+	n := checkVecSize(len(out), len(xVec), len(xVec))
+	if n == 0 {
+		return
+	}
+	tmp := make([]Fr, n)
+	C.gomclBnFrBatchInv(out[0].getPointer(), xVec[0].getPointer(), tmp[0].getPointer(), (C.size_t)(n))
+}
+
 // FrDiv --
 func FrDiv(out *Fr, x *Fr, y *Fr) {
 	C.mclBnFr_div(out.getPointer(), x.getPointer(), y.getPointer())
@@ -512,6 +652,16 @@ func FpInv(out *Fp, x *Fp) {
 	C.mclBnFp_inv(out.getPointer(), x.getPointer())
 }
 
+// FpBatchInv -- out[i] = 1 / xVec[i] with a single inversion, zero elements stay zero
+func FpBatchInv(out []Fp, xVec []Fp) {
+	n := checkVecSize(len(out), len(xVec), len(xVec))
+	if n == 0 {
+		return
+	}
+	tmp := make([]Fp, n)
+	C.gomclBnFpBatchInv(out[0].getPointer(), xVec[0].getPointer(), tmp[0].getPointer(), (C.size_t)(n))
+}
+
 // FpSqr --
 func FpSqr(out *Fp, x *Fp) {
 	C.mclBnFp_sqr(out.getPointer(), x.getPointer())
@@ -794,6 +944,16 @@ func G1Normalize(out *G1, x *G1) {
 	C.mclBnG1_normalize(out.getPointer(), x.getPointer())
 }
 
+// G1NormalizeVec -- out[i] = normalized xVec[i] with a single inversion in a single cgo call
+func G1NormalizeVec(out []G1, xVec []G1) {
+	n := checkVecSize(len(out), len(xVec), len(xVec))
+	if n == 0 {
+		return
+	}
+	zInv, tmp := make([]Fp, n), make([]Fp, n)
+	C.goG1NormalizeVec(out[0].getPointer(), xVec[0].getPointer(), zInv[0].getPointer(), tmp[0].getPointer(), (C.size_t)(n))
+}
+
 // G1Neg --
 func G1Neg(out *G1, x *G1) {
 	C.mclBnG1_neg(out.getPointer(), x.getPointer())
@@ -980,6 +1140,16 @@ func G2Normalize(out *G2, x *G2) {
 	C.mclBnG2_normalize(out.getPointer(), x.getPointer())
 }
 
+// G2NormalizeVec -- out[i] = normalized xVec[i] with a single inversion in a single cgo call
+func G2NormalizeVec(out []G2, xVec []G2) {
+	n := checkVecSize(len(out), len(xVec), len(xVec))
+	if n == 0 {
+		return
+	}
+	zInv, tmp := make([]Fp2, n), make([]Fp2, n)
+	C.goG2NormalizeVec(out[0].getPointer(), xVec[0].getPointer(), zInv[0].getPointer(), tmp[0].getPointer(), (C.size_t)(n))
+}
+
 // G2Neg --
 func G2Neg(out *G2, x *G2) {
 	C.mclBnG2_neg(out.getPointer(), x.getPointer())
@@ -1109,6 +1279,11 @@ func GTInv(out *GT, x *GT) {
 	C.mclBnGT_inv(out.getPointer(), x.getPointer())
 }
 
+// GTInvGeneric -- inverse of any non zero x in Fp12
+func GTInvGeneric(out *GT, x *GT) {
+	C.mclBnGT_invGeneric(out.getPointer(), x.getPointer())
+}
+
 // GTAdd --
 func GTAdd(out *GT, x *GT, y *GT) {
 	C.mclBnGT_add(out.getPointer(), x.getPointer(), y.getPointer())
@@ -1134,6 +1309,23 @@ func GTPow(out *GT, x *GT, y *Fr) {
 	C.mclBnGT_pow(out.getPointer(), x.getPointer(), y.getPointer())
 }
 
+// GTPowGeneric -- power of any x in Fp12
+func GTPowGeneric(out *GT, x *GT, y *Fr) {
+	C.mclBnGT_powGeneric(out.getPointer(), x.getPointer(), y.getPointer())
+}
+
+// GetFp12 -- tower coefficients of x
+func (x *GT) GetFp12() Fp12 {
+	// #nosec
+	return *(*Fp12)(unsafe.Pointer(x))
+}
+
+// SetFp12 --
+func (x *GT) SetFp12(y *Fp12) {
+	// #nosec
+	*(*Fp12)(unsafe.Pointer(x)) = *y
+}
+
 // MapToG1 --
 func MapToG1(out *G1, x *Fp) error {
 	if C.mclBnFp_mapToG1(out.getPointer(), x.getPointer()) != 0 {
@@ -1201,7 +1393,18 @@ func PrecomputedMillerLoop(out *GT, P *G1, Qbuf []uint64) {
 // PrecomputedMillerLoop2 --
 func PrecomputedMillerLoop2(out *GT, P1 *G1, Q1buf []uint64, P2 *G1, Q2buf []uint64) {
 	// #nosec
-	C.mclBn_precomputedMillerLoop2(out.getPointer(), P1.getPointer(), (*C.uint64_t)(unsafe.Pointer(&Q1buf[0])), P1.getPointer(), (*C.uint64_t)(unsafe.Pointer(&Q1buf[0])))
+	C.mclBn_precomputedMillerLoop2(out.getPointer(), P1.getPointer(), (*C.uint64_t)(unsafe.Pointer(&Q1buf[0])), P2.getPointer(), (*C.uint64_t)(unsafe.Pointer(&Q2buf[0])))
+}
+
+// blsVerify checks e(signature, G2) e(-H, publicKey) == 1 in a single cgo call. H is messagePoint
+// if it is not nil, otherwise it is the HashToG107 point of 96 uniform bytes of expand_message_xmd
+func blsVerify(signature *G1, publicKey *G2, messagePoint *G1, uniform []byte) bool {
+	var u *C.uchar
+	if messagePoint == nil {
+		u = (*C.uchar)(unsafe.Pointer(&uniform[0]))
+	}
+	// #nosec
+	return C.goBlsVerify(signature.getPointer(), publicKey.getPointer(), messagePoint.getPointer(), u, (*C.uint64_t)(unsafe.Pointer(&qCoef[0]))) == 1
 }
 
 // FrEvaluatePolynomial -- y = c[0] + c[1] * x + c[2] * x^2 + ...
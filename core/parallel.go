@@ -0,0 +1,199 @@
+package core
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+const (
+	// parallelMinChunk is the minimal number of elements summed by one goroutine,
+	// smaller inputs are not worth the goroutine overhead
+	parallelMinChunk = 256
+	// parallelCheckEvery is the number of additions between checks of the context
+	parallelCheckEvery = 64
+)
+
+// AggregatePublicKeysParallel calculates P1 + P2 + ... like AggregatePublicKeys, splitting the keys
+// across at most workers goroutines. If workers is not positive, GOMAXPROCS is used.
+// The error is returned only if ctx is done before the sum is calculated
+func AggregatePublicKeysParallel(ctx context.Context, pubs []*PublicKey, workers int) (*PublicKey, error) {
+	partials := make([]G2, parallelChunks(len(pubs), workers))
+
+	err := parallelAggregate(ctx, len(pubs), len(partials),
+		func(chunk, i int) {
+			if pubs[i].p != nil {
+				G2Add(&partials[chunk], &partials[chunk], pubs[i].p)
+			}
+		},
+		func(dst, src int) {
+			G2Add(&partials[dst], &partials[dst], &partials[src])
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(G2)
+
+	if len(partials) > 0 {
+		G2Normalize(res, &partials[0])
+	}
+
+	return newPublicKey(res), nil
+}
+
+// AggregateSignaturesParallel sums the given signatures like AggregateSignatures, splitting them
+// across at most workers goroutines. If workers is not positive, GOMAXPROCS is used.
+// The error is returned only if ctx is done before the sum is calculated
+func AggregateSignaturesParallel(ctx context.Context, signatures []*Signature, workers int) (*Signature, error) {
+	partials := make([]G1, parallelChunks(len(signatures), workers))
+
+	err := parallelAggregate(ctx, len(signatures), len(partials),
+		func(chunk, i int) {
+			if signatures[i].p != nil {
+				G1Add(&partials[chunk], &partials[chunk], signatures[i].p)
+			}
+		},
+		func(dst, src int) {
+			G1Add(&partials[dst], &partials[dst], &partials[src])
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(G1)
+
+	if len(partials) > 0 {
+		G1Normalize(res, &partials[0])
+	}
+
+	return newSignature(res), nil
+}
+
+// parallelChunks returns the number of chunks n elements are split into for the given number of workers
+func parallelChunks(n, workers int) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	chunks := (n + parallelMinChunk - 1) / parallelMinChunk
+	if chunks > workers {
+		chunks = workers
+	}
+
+	return chunks
+}
+
+// G1MulVecParallel -- multi scalar multiplication out = sum mul(xVec[i], yVec[i]) like G1MulVec,
+// splitting the vectors in chunks calculated by at most workers goroutines. If workers is not positive,
+// GOMAXPROCS is used
+func G1MulVecParallel(out *G1, xVec []G1, yVec []Fr, workers int) {
+	if len(xVec) != len(yVec) {
+		panic("xVec and yVec have the same size")
+	}
+
+	partials := make([]G1, parallelChunks(len(xVec), workers))
+
+	_ = parallelReduce(context.Background(), len(xVec), len(partials),
+		func(chunk, from, to int) {
+			G1MulVec(&partials[chunk], xVec[from:to], yVec[from:to])
+		},
+		func(dst, src int) {
+			G1Add(&partials[dst], &partials[dst], &partials[src])
+		})
+
+	if len(partials) == 0 {
+		out.Clear()
+
+		return
+	}
+
+	*out = partials[0]
+}
+
+// G2MulVecParallel -- multi scalar multiplication out = sum mul(xVec[i], yVec[i]) like G2MulVec,
+// splitting the vectors in chunks calculated by at most workers goroutines. If workers is not positive,
+// GOMAXPROCS is used
+func G2MulVecParallel(out *G2, xVec []G2, yVec []Fr, workers int) {
+	if len(xVec) != len(yVec) {
+		panic("xVec and yVec have the same size")
+	}
+
+	partials := make([]G2, parallelChunks(len(xVec), workers))
+
+	_ = parallelReduce(context.Background(), len(xVec), len(partials),
+		func(chunk, from, to int) {
+			G2MulVec(&partials[chunk], xVec[from:to], yVec[from:to])
+		},
+		func(dst, src int) {
+			G2Add(&partials[dst], &partials[dst], &partials[src])
+		})
+
+	if len(partials) == 0 {
+		out.Clear()
+
+		return
+	}
+
+	*out = partials[0]
+}
+
+// parallelAggregate sums n elements in chunks goroutines, add(chunk, i) adds element i to the
+// partial sum of the chunk. Partial sums are then reduced with a tree of merge(dst, src) calls,
+// so the total ends up in the partial sum of the chunk 0
+func parallelAggregate(ctx context.Context, n, chunks int, add func(chunk, i int), merge func(dst, src int)) error {
+	return parallelReduce(ctx, n, chunks,
+		func(chunk, from, to int) {
+			for i := from; i < to; i++ {
+				if i%parallelCheckEvery == 0 && ctx.Err() != nil {
+					return
+				}
+
+				add(chunk, i)
+			}
+		},
+		merge)
+}
+
+// parallelReduce splits n elements in chunks calculated by sum(chunk, from, to) in separate goroutines.
+// Results of chunks are then reduced with a tree of merge(dst, src) calls, so the total ends up in
+// the result of the chunk 0
+func parallelReduce(ctx context.Context, n, chunks int, sum func(chunk, from, to int), merge func(dst, src int)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+
+	for chunk := 0; chunk < chunks; chunk++ {
+		wg.Add(1)
+
+		go func(chunk int) {
+			defer wg.Done()
+
+			sum(chunk, chunk*n/chunks, (chunk+1)*n/chunks)
+		}(chunk)
+	}
+
+	wg.Wait()
+
+	for step := 1; step < chunks; step *= 2 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		for dst := 0; dst+step < chunks; dst += 2 * step {
+			wg.Add(1)
+
+			go func(dst int) {
+				defer wg.Done()
+
+				merge(dst, dst+step)
+			}(dst)
+		}
+
+		wg.Wait()
+	}
+
+	return ctx.Err()
+}
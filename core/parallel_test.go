@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AggregateParallel(t *testing.T) {
+	t.Parallel()
+
+	const count = 3*parallelMinChunk + 7
+
+	var x Fr
+
+	publicKeys, signatures := make([]*PublicKey, count), make([]*Signature, count)
+
+	for i := 0; i < count; i++ {
+		require.True(t, x.SetByCSPRNG())
+
+		p, q := new(G1), new(G2)
+
+		G1MulGenerator(p, &x)
+		G2MulGenerator(q, &x)
+
+		signatures[i], publicKeys[i] = newSignature(p), newPublicKey(q)
+	}
+
+	// empty values are skipped like in the sequential aggregation
+	signatures[5], publicKeys[count-1] = &Signature{}, &PublicKey{}
+
+	for _, n := range []int{0, 1, parallelMinChunk, count} {
+		expectedSignature := AggregateSignatures(signatures[:n])
+		expectedPublicKey := AggregatePublicKeys(publicKeys[:n])
+
+		for _, workers := range []int{0, 1, 2, 3, 16} {
+			signature, err := AggregateSignaturesParallel(context.Background(), signatures[:n], workers)
+			require.NoError(t, err)
+			assert.True(t, expectedSignature.p.IsEqual(signature.p), "n %d workers %d", n, workers)
+
+			publicKey, err := AggregatePublicKeysParallel(context.Background(), publicKeys[:n], workers)
+			require.NoError(t, err)
+			assert.True(t, expectedPublicKey.p.IsEqual(publicKey.p), "n %d workers %d", n, workers)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	signature, err := AggregateSignaturesParallel(ctx, signatures, 4)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, signature)
+
+	publicKey, err := AggregatePublicKeysParallel(ctx, publicKeys, 4)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, publicKey)
+}
+
+func Test_MulVecParallel(t *testing.T) {
+	t.Parallel()
+
+	const count = 2*parallelMinChunk + 3
+
+	scalars := testGenRandomFrs(t, count)
+	g1s, g2s := make([]G1, count), make([]G2, count)
+
+	for i := range scalars {
+		G1MulGenerator(&g1s[i], &scalars[i])
+		G2MulGenerator(&g2s[i], &scalars[i])
+	}
+
+	scalars = testGenRandomFrs(t, count)
+
+	for _, n := range []int{0, 1, parallelMinChunk + 1, count} {
+		var (
+			expected1, out1 G1
+			expected2, out2 G2
+		)
+
+		G1MulVec(&expected1, g1s[:n], scalars[:n])
+		G2MulVec(&expected2, g2s[:n], scalars[:n])
+
+		for _, workers := range []int{0, 1, 2, 3} {
+			G1MulVecParallel(&out1, g1s[:n], scalars[:n], workers)
+			assert.True(t, expected1.IsEqual(&out1), "n %d workers %d", n, workers)
+
+			G2MulVecParallel(&out2, g2s[:n], scalars[:n], workers)
+			assert.True(t, expected2.IsEqual(&out2), "n %d workers %d", n, workers)
+		}
+	}
+
+	assert.Panics(t, func() { G1MulVecParallel(new(G1), g1s, scalars[1:], 2) })
+	assert.Panics(t, func() { G2MulVecParallel(new(G2), g2s[1:], scalars, 2) })
+}
+
+func Benchmark_G1MulVecParallel(b *testing.B) {
+	const count = 8192
+
+	scalars := testGenRandomFrs(b, count)
+	points := make([]G1, count)
+
+	for i := range scalars {
+		G1MulGenerator(&points[i], &scalars[i])
+	}
+
+	scalars = testGenRandomFrs(b, count)
+
+	var out G1
+
+	b.Run("G1MulVec", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			G1MulVec(&out, points, scalars)
+		}
+	})
+
+	b.Run("G1MulVecParallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			G1MulVecParallel(&out, points, scalars, 0)
+		}
+	})
+}
@@ -0,0 +1,187 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// evmG1Size and evmG2Size are sizes of EIP-196/197 point encodings
+	evmG1Size = 64
+	evmG2Size = 128
+	// evmPairSize is the size of a single (G1, G2) pair in the ECPairing input
+	evmPairSize = evmG1Size + evmG2Size
+)
+
+var (
+	// ErrPrecompileCurve is returned by precompile functions when the curve is not CurveSNARK1 (alt_bn128)
+	ErrPrecompileCurve = errors.New("precompile requires CurveSNARK1")
+	// ErrPairingInputLength is returned by ECPairing when the input length is not a multiple of 192
+	ErrPairingInputLength = errors.New("pairing input length is not a multiple of 192")
+)
+
+// ECAdd computes the EIP-196 ECADD precompile (address 0x06). Input is zero padded or truncated
+// to 128 bytes holding two EVM encoded G1 points, the output is 64 bytes EVM encoded sum
+func ECAdd(input []byte) ([]byte, error) {
+	if GetCurveType() != CurveSNARK1 {
+		return nil, ErrPrecompileCurve
+	}
+
+	input = evmInput(input, 2*evmG1Size)
+
+	p1, err := evmG1FromBytes(input[:evmG1Size])
+	if err != nil {
+		return nil, err
+	}
+
+	p2, err := evmG1FromBytes(input[evmG1Size:])
+	if err != nil {
+		return nil, err
+	}
+
+	G1Add(p1, p1, p2)
+
+	return G1ToEVM(p1), nil
+}
+
+// ECMul computes the EIP-196 ECMUL precompile (address 0x07). Input is zero padded or truncated
+// to 96 bytes holding EVM encoded G1 point and 32 bytes big-endian scalar, the output is 64 bytes
+// EVM encoded product. Scalar is not required to be less than the curve order
+func ECMul(input []byte) ([]byte, error) {
+	if GetCurveType() != CurveSNARK1 {
+		return nil, ErrPrecompileCurve
+	}
+
+	input = evmInput(input, evmG1Size+32)
+
+	p, err := evmG1FromBytes(input[:evmG1Size])
+	if err != nil {
+		return nil, err
+	}
+
+	var scalar Fr
+
+	if err := scalar.SetBigEndianMod(input[evmG1Size:]); err != nil {
+		return nil, err
+	}
+
+	G1Mul(p, p, &scalar)
+
+	return G1ToEVM(p), nil
+}
+
+// ECPairing computes the EIP-197 ECPAIRING precompile (address 0x08). Input is a sequence of
+// EVM encoded (G1, G2) pairs, G2 points must be in the prime-order subgroup. The output is
+// 32 bytes big-endian 1 if the product of pairings is one and 0 otherwise. Empty input yields 1
+func ECPairing(input []byte) ([]byte, error) {
+	if GetCurveType() != CurveSNARK1 {
+		return nil, ErrPrecompileCurve
+	}
+
+	if len(input)%evmPairSize != 0 {
+		return nil, fmt.Errorf("%w: %d", ErrPairingInputLength, len(input))
+	}
+
+	n := len(input) / evmPairSize
+	g1s, g2s := make([]G1, 0, n), make([]G2, 0, n)
+
+	for i := 0; i < n; i++ {
+		raw := input[i*evmPairSize : (i+1)*evmPairSize]
+
+		p, err := evmG1FromBytes(raw[:evmG1Size])
+		if err != nil {
+			return nil, err
+		}
+
+		q, err := evmG2FromBytes(raw[evmG1Size:])
+		if err != nil {
+			return nil, err
+		}
+
+		// pairing with the identity is one, so the pair does not change the product
+		if p.IsZero() || q.IsZero() {
+			continue
+		}
+
+		g1s, g2s = append(g1s, *p), append(g2s, *q)
+	}
+
+	res := make([]byte, 32)
+	if PairingCheck(g1s, g2s) {
+		res[31] = 1
+	}
+
+	return res, nil
+}
+
+// PairingCheck returns true if prod_i e(g1s[i], g2s[i]) is one. Slices of different length are rejected
+func PairingCheck(g1s []G1, g2s []G2) bool {
+	if len(g1s) != len(g2s) {
+		return false
+	}
+
+	if len(g1s) == 0 {
+		return true
+	}
+
+	var e GT
+
+	MillerLoopVec(&e, g1s, g2s)
+	FinalExp(&e, &e)
+
+	return e.IsOne()
+}
+
+// evmInput pads input with zeros on the right or truncates it to the given size like EVM calldata
+func evmInput(input []byte, size int) []byte {
+	if len(input) >= size {
+		return input[:size]
+	}
+
+	res := make([]byte, size)
+	copy(res, input)
+
+	return res
+}
+
+// evmG1FromBytes decodes EVM encoded point and checks it is on the curve.
+// The subgroup check is not needed as the cofactor of G1 is one
+func evmG1FromBytes(raw []byte) (*G1, error) {
+	p, err := G1FromEVM(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.IsZero() {
+		return p, nil
+	}
+
+	left, right := new(Fp), new(Fp)
+
+	FpSqr(left, &p.Y)
+	g1Weierstrass(right, &p.X)
+
+	if !left.IsEqual(right) {
+		return nil, ErrNotOnCurve
+	}
+
+	return p, nil
+}
+
+// evmG2FromBytes decodes EVM encoded point and checks it is on the twist curve and in the subgroup
+func evmG2FromBytes(raw []byte) (*G2, error) {
+	q, err := G2FromEVM(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.IsZero() {
+		return q, nil
+	}
+
+	if err := ValidateG2(q); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// precompileVector holds input and output of EIP-196/197 precompile. Vectors named chfast1 and jeff1
+// are taken from go-ethereum precompile test data, others are edge cases of the precompile semantics.
+// All of them are checked against go-ethereum by testdata/precompilegen
+type precompileVector struct {
+	Name     string `json:"name"`
+	Input    string `json:"input"`
+	Expected string `json:"expected"`
+	Error    string `json:"error"`
+}
+
+type precompileVectors struct {
+	Add     []precompileVector `json:"add"`
+	Mul     []precompileVector `json:"mul"`
+	Pairing []precompileVector `json:"pairing"`
+}
+
+var precompileErrors = map[string]error{
+	"nonCanonical":  ErrNonCanonical,
+	"notOnCurve":    ErrNotOnCurve,
+	"notInSubgroup": ErrNotInSubgroup,
+	"inputLength":   ErrPairingInputLength,
+}
+
+func Test_PrecompileVectors(t *testing.T) {
+	t.Parallel()
+
+	raw, err := os.ReadFile("testdata/precompile_vectors.json")
+	require.NoError(t, err)
+
+	var vectors precompileVectors
+
+	require.NoError(t, json.Unmarshal(raw, &vectors))
+
+	for _, c := range []struct {
+		name       string
+		precompile func([]byte) ([]byte, error)
+		vectors    []precompileVector
+	}{
+		{"add", ECAdd, vectors.Add},
+		{"mul", ECMul, vectors.Mul},
+		{"pairing", ECPairing, vectors.Pairing},
+	} {
+		for _, v := range c.vectors {
+			input, err := hex.DecodeString(v.Input)
+			require.NoError(t, err)
+
+			output, err := c.precompile(input)
+
+			if v.Error != "" {
+				require.Contains(t, precompileErrors, v.Error)
+				assert.ErrorIs(t, err, precompileErrors[v.Error], c.name+" "+v.Name)
+				assert.Nil(t, output, c.name+" "+v.Name)
+
+				continue
+			}
+
+			require.NoError(t, err, c.name+" "+v.Name)
+			assert.Equal(t, v.Expected, hex.EncodeToString(output), c.name+" "+v.Name)
+		}
+	}
+}
+
+// gethPrecompileVector is a vector of go-ethereum core/vm/testdata/precompiles copied to testdata/geth
+type gethPrecompileVector struct {
+	Name     string
+	Input    string
+	Expected string
+}
+
+func Test_PrecompileGethVectors(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []struct {
+		file       string
+		precompile func([]byte) ([]byte, error)
+	}{
+		{"bn256Add.json", ECAdd},
+		{"bn256ScalarMul.json", ECMul},
+		{"bn256Pairing.json", ECPairing},
+	} {
+		raw, err := os.ReadFile("testdata/geth/" + c.file)
+		require.NoError(t, err)
+
+		var vectors []gethPrecompileVector
+
+		require.NoError(t, json.Unmarshal(raw, &vectors))
+		require.NotEmpty(t, vectors)
+
+		for _, v := range vectors {
+			input, err := hex.DecodeString(v.Input)
+			require.NoError(t, err)
+
+			output, err := c.precompile(input)
+			require.NoError(t, err, c.file+" "+v.Name)
+			assert.Equal(t, v.Expected, hex.EncodeToString(output), c.file+" "+v.Name)
+		}
+	}
+}
+
+func Test_PairingCheck(t *testing.T) {
+	t.Parallel()
+
+	var (
+		x    Fr
+		p, q G1
+		r    G2
+	)
+
+	require.True(t, x.SetByCSPRNG())
+
+	// e(xG1, G2) e(-G1, xG2) = 1
+	G1Mul(&p, ellipticCurveG1, &x)
+	G1Neg(&q, ellipticCurveG1)
+	G2Mul(&r, ellipticCurveG2, &x)
+
+	assert.True(t, PairingCheck([]G1{p, q}, []G2{*ellipticCurveG2, r}))
+	assert.False(t, PairingCheck([]G1{p, p}, []G2{*ellipticCurveG2, r}))
+	assert.False(t, PairingCheck([]G1{p}, []G2{*ellipticCurveG2}))
+	assert.False(t, PairingCheck([]G1{p, q}, []G2{*ellipticCurveG2}))
+	assert.True(t, PairingCheck(nil, nil))
+}
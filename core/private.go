@@ -17,23 +17,25 @@ type PrivateKey struct {
 func (p *PrivateKey) PublicKey() *PublicKey {
 	public := new(G2)
 
-	G2Mul(public, ellipticCurveG2, p.p)
+	G2MulGenerator(public, p.p)
 
-	return &PublicKey{p: public}
+	return newPublicKey(public)
 }
 
 // Sign generates a signature of the given message
 func (p *PrivateKey) Sign(message []byte) (*Signature, error) {
-	messagePoint, err := HashToG1(message)
-	if err != nil {
+	g1 := new(G1)
+
+	s := hashScratchPool.Get().(*hashScratch)
+	defer hashScratchPool.Put(s)
+
+	if err := hashToG1Into(g1, message, s); err != nil {
 		return nil, err
 	}
 
-	g1 := new(G1)
-
-	G1Mul(g1, messagePoint, p.p)
+	G1Mul(g1, g1, p.p)
 
-	return &Signature{p: g1}, nil
+	return newSignature(g1), nil
 }
 
 // MarshalJSON marshal the key to bytes.
@@ -38,3 +38,24 @@ func Test_PrivateMarshal1(t *testing.T) {
 
 	require.Equal(t, messagePoint, g1)
 }
+
+func FuzzUnmarshalPrivateKey(f *testing.F) {
+	key, err := GenerateBlsKey()
+	require.NoError(f, err)
+
+	raw, err := key.MarshalJSON()
+	require.NoError(f, err)
+
+	f.Add(raw)
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		key, err := UnmarshalPrivateKey(raw)
+		if err != nil {
+			return
+		}
+
+		res, err := key.MarshalJSON()
+		require.NoError(t, err)
+		require.Equal(t, raw, res)
+	})
+}
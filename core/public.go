@@ -5,26 +5,62 @@ import (
 	"fmt"
 )
 
-// PublicKey represents bls public key
+// PublicKey represents bls public key. The point is normalized at construction
+// and never modified afterwards, so PublicKey is safe for concurrent use
 type PublicKey struct {
 	p *G2
 }
 
+// newPublicKey normalizes the point and wraps it. The point must not be shared
+func newPublicKey(p *G2) *PublicKey {
+	G2Normalize(p, p)
+
+	return &PublicKey{p: p}
+}
+
 // Aggregate aggregates current key with key passed as a parameter
 func (p *PublicKey) Aggregate(next *PublicKey) *PublicKey {
-	newp := new(G2)
+	return p.AggregateInto(new(PublicKey), next)
+}
 
-	if p.p != nil {
-		if next.p != nil {
-			G2Add(newp, p.p, next.p)
-		} else {
-			G2Add(newp, newp, p.p)
-		}
-	} else if next.p != nil {
-		G2Add(newp, newp, next.p)
+// AggregateInto sets dst to the sum of p and next and returns dst. The point of dst is reused,
+// so accumulating with p.AggregateInto(p, next) does not allocate. As dst is modified,
+// it must not be shared with other goroutines until aggregation is done
+func (p *PublicKey) AggregateInto(dst *PublicKey, next *PublicKey) *PublicKey {
+	if dst.p == nil {
+		dst.p = new(G2)
+	}
+
+	switch {
+	case p.p != nil && next.p != nil:
+		G2Add(dst.p, p.p, next.p)
+	case p.p != nil:
+		*dst.p = *p.p
+	case next.p != nil:
+		*dst.p = *next.p
+	default:
+		dst.p.Clear()
 	}
 
-	return &PublicKey{p: newp}
+	G2Normalize(dst.p, dst.p)
+
+	return dst
+}
+
+// Remove subtracts the given key from the aggregated one, e.g. to exclude a signer of p
+func (p *PublicKey) Remove(pub *PublicKey) *PublicKey {
+	res := new(G2)
+
+	switch {
+	case p.p != nil && pub.p != nil:
+		G2Sub(res, p.p, pub.p)
+	case p.p != nil:
+		*res = *p.p
+	case pub.p != nil:
+		G2Neg(res, pub.p)
+	}
+
+	return newPublicKey(res)
 }
 
 // Marshal marshals public key to bytes.
@@ -36,6 +72,24 @@ func (p *PublicKey) Marshal() []byte {
 	return G2ToBytes(p.p)
 }
 
+// MarshalCompressed marshals public key to 64 bytes compressed form.
+func (p *PublicKey) MarshalCompressed() []byte {
+	if p.p == nil {
+		return nil
+	}
+
+	return G2ToCompressedBytes(p.p)
+}
+
+// MarshalEVM marshals public key to bytes in EIP-197 precompile layout.
+func (p *PublicKey) MarshalEVM() []byte {
+	if p.p == nil {
+		return nil
+	}
+
+	return G2ToEVM(p.p)
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (p *PublicKey) MarshalJSON() ([]byte, error) {
 	return json.Marshal(p.Marshal())
@@ -57,22 +111,39 @@ func (p *PublicKey) UnmarshalJSON(raw []byte) error {
 		return err
 	}
 
-	p.p, err = G2FromBytes(jsonBytes)
+	g2, err := g2FromBytesStrict(jsonBytes)
 	if err != nil {
 		return err
 	}
 
+	p.p = newPublicKey(g2).p
+
 	return nil
 }
 
-// UnmarshalPublicKey reads the public key from the given byte array
+// UnmarshalPublicKey reads the public key from the given byte array.
+// Both uncompressed and compressed forms are accepted. Invalid points and the identity element are rejected
 func UnmarshalPublicKey(raw []byte) (*PublicKey, error) {
-	g2, err := G2FromBytes(raw)
+	g2, err := g2FromBytesStrict(raw)
 	if err != nil {
 		return nil, err
 	}
 
-	return &PublicKey{p: g2}, nil
+	return newPublicKey(g2), nil
+}
+
+// UnmarshalPublicKeyEVM reads the public key from the given byte array in EIP-197 precompile layout
+func UnmarshalPublicKeyEVM(raw []byte) (*PublicKey, error) {
+	g2, err := G2FromEVM(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateG2(g2); err != nil {
+		return nil, err
+	}
+
+	return newPublicKey(g2), nil
 }
 
 // CollectPublicKeys colects public keys from slice of private keys
@@ -88,13 +159,53 @@ func CollectPublicKeys(keys []*PrivateKey) []*PublicKey {
 
 // AggregatePublicKeys calculates P1 + P2 + ...
 func AggregatePublicKeys(pubs []*PublicKey) *PublicKey {
-	newp := new(G2)
+	return AggregatePublicKeysInto(new(PublicKey), pubs)
+}
+
+// AggregatePublicKeysInto sets dst to P1 + P2 + ... and returns dst.
+// The point of dst is reused, so dst must not be one of the keys nor shared with other goroutines
+func AggregatePublicKeysInto(dst *PublicKey, pubs []*PublicKey) *PublicKey {
+	if dst.p == nil {
+		dst.p = new(G2)
+	}
+
+	aggregatePublicKeysInto(dst.p, pubs)
+	G2Normalize(dst.p, dst.p)
+
+	return dst
+}
+
+func aggregatePublicKeysInto(dst *G2, pubs []*PublicKey) {
+	dst.Clear()
 
 	for _, x := range pubs {
 		if x.p != nil {
-			G2Add(newp, newp, x.p)
+			G2Add(dst, dst, x.p)
 		}
 	}
+}
+
+// g2FromBytesStrict decodes compressed or uncompressed point depending on the length of the input
+// and checks that the point is valid, see ValidateG2
+func g2FromBytesStrict(raw []byte) (*G2, error) {
+	var (
+		g2  *G2
+		err error
+	)
+
+	if len(raw) == 64 {
+		g2, err = G2FromCompressedBytes(raw)
+	} else {
+		g2, err = G2FromBytes(raw)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateG2(g2); err != nil {
+		return nil, err
+	}
 
-	return &PublicKey{p: newp}
+	return g2, nil
 }
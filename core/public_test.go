@@ -47,3 +47,55 @@ func TestPublic_MarshalUnmarshalJSON(t *testing.T) {
 	assert.Equal(t, pubKey, newPubKey)
 	require.Equal(t, marshaledPubKey, dt)
 }
+
+func FuzzUnmarshalPublicKey(f *testing.F) {
+	key, err := GenerateBlsKey()
+	require.NoError(f, err)
+
+	f.Add(key.PublicKey().Marshal())
+	f.Add(key.PublicKey().MarshalCompressed())
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		pub, err := UnmarshalPublicKey(raw)
+		if err != nil {
+			return
+		}
+
+		if len(raw) == 64 {
+			require.Equal(t, raw, pub.MarshalCompressed())
+		} else {
+			require.Equal(t, raw, pub.Marshal())
+		}
+	})
+}
+
+func FuzzUnmarshalPublicKeyEVM(f *testing.F) {
+	key, err := GenerateBlsKey()
+	require.NoError(f, err)
+
+	f.Add(key.PublicKey().MarshalEVM())
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		pub, err := UnmarshalPublicKeyEVM(raw)
+		if err == nil {
+			require.Equal(t, raw, pub.MarshalEVM())
+		}
+	})
+}
+
+func FuzzPublicKeyUnmarshalJSON(f *testing.F) {
+	key, err := GenerateBlsKey()
+	require.NoError(f, err)
+
+	raw, err := key.PublicKey().MarshalJSON()
+	require.NoError(f, err)
+
+	f.Add(raw)
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		pub := new(PublicKey)
+		if err := pub.UnmarshalJSON(raw); err == nil {
+			require.NoError(t, ValidateG2(pub.p))
+		}
+	})
+}
@@ -0,0 +1,440 @@
+//go:build purego
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// CurveFp254BNb -- 254 bit curve
+const CurveFp254BNb = 0
+
+// 254-bit BN curve with support for roots of unity
+const CurveSNARK1 = 4
+
+// CurveFp382_1 -- 382 bit curve 1
+const CurveFp382_1 = 1
+
+// CurveFp382_2 -- 382 bit curve 2
+const CurveFp382_2 = 2
+
+// BLS12_381 --
+const BLS12_381 = 5
+
+// IRTF -- for SetMapToMode
+const IRTF = 5 /* MCL_MAP_TO_MODE_HASH_TO_CURVE_07 */
+
+var errUnsupportedByPurego = errors.New("not supported by the pure Go backend")
+
+// bnCurveParam -- BN curve y^2 = x^3 + b over Fp with parameter u and twist y^2 = x^3 + b / xi over Fp2
+// where xi = xiA + i. Mirrors mcl CurveParam
+type bnCurveParam struct {
+	u   string
+	b   int64
+	xiA int64
+}
+
+var bnCurveParams = map[int]bnCurveParam{
+	CurveFp254BNb: {u: "-0x4080000000000001", b: 2, xiA: 1},
+	CurveSNARK1:   {u: "4965661367192848881", b: 3, xiA: 9},
+}
+
+// bnCurve -- initialized curve constants
+type bnCurve struct {
+	u        *big.Int
+	xiA      Fp
+	b        Fp
+	bTwist   Fp2
+	p, r     *big.Int
+	loopNAF  []int8 // NAF of |6u + 2|, least significant digit first
+	hardPart *big.Int
+	// frobeniusCoef[k] = xi^(k(p - 1) / 6)
+	frobeniusCoef [6]Fp2
+	// mapToC1 = sqrt(-3), mapToC2 = (mapToC1 - 1) / 2
+	mapToC1, mapToC2 Fp
+}
+
+var (
+	bn        bnCurve
+	curveType int
+
+	verifyOrderG1, verifyOrderG2 bool
+)
+
+// puregoVersion -- mcl version whose behaviour is reproduced by the pure Go backend
+const puregoVersion = 0x176
+
+// GetVersion -- return 0xABC which means mcl version A.BC
+func GetVersion() int {
+	return puregoVersion
+}
+
+// GetCurveType -- return the initialized curve
+func GetCurveType() int {
+	return curveType
+}
+
+// InitCurve --
+func InitCurve(curve int) error {
+	param, ok := bnCurveParams[curve]
+	if !ok {
+		return fmt.Errorf("mclBn_init curve %d error: %w", curve, errUnsupportedByPurego)
+	}
+
+	u, _ := new(big.Int).SetString(param.u, 0)
+
+	// p = 36u^4 + 36u^3 + 24u^2 + 6u + 1, r = 36u^4 + 36u^3 + 18u^2 + 6u + 1
+	p := bnPolynomial(u, 36, 36, 24, 6, 1)
+	r := bnPolynomial(u, 36, 36, 18, 6, 1)
+
+	fpField, frField = newMontField(p), newMontField(r)
+
+	var c bnCurve
+
+	c.u, c.p, c.r = u, p, r
+	c.xiA.SetInt64(param.xiA)
+	c.b.SetInt64(param.b)
+
+	// bTwist = b / xi
+	var xi Fp2
+
+	xi.D[0] = c.xiA
+	xi.D[1].SetInt64(1)
+	c.bTwist.D[0] = c.b
+	Fp2Div(&c.bTwist, &c.bTwist, &xi)
+
+	loop := new(big.Int).Mul(u, big.NewInt(6))
+	loop.Add(loop, big.NewInt(2))
+	c.loopNAF = toNAF(loop.Abs(loop))
+
+	// mcl computes the hard part of the final exponentiation as in "Faster Hashing to G2" which raises
+	// to 2u(6u^2 + 3u + 1)(p^4 - p^2 + 1) / r. The multiplier may be reduced mod r because
+	// the result of the easy part has order dividing p^4 - p^2 + 1
+	p2 := new(big.Int).Mul(p, p)
+	c.hardPart = new(big.Int).Mul(p2, p2)
+	c.hardPart.Sub(c.hardPart, p2).Add(c.hardPart, big.NewInt(1)).Div(c.hardPart, r)
+	c.hardPart.Mul(c.hardPart, new(big.Int).Mod(bnPolynomial(u, 0, 12, 6, 2, 0), r))
+
+	e := new(big.Int).Sub(p, big.NewInt(1))
+	e.Div(e, big.NewInt(6))
+
+	for k := range c.frobeniusCoef {
+		fp2Exp(&c.frobeniusCoef[k], &xi, new(big.Int).Mul(e, big.NewInt(int64(k))))
+	}
+
+	c.mapToC1.SetInt64(-3)
+	FpSquareRoot(&c.mapToC1, &c.mapToC1)
+
+	var one, two Fp
+
+	one.SetInt64(1)
+	two.SetInt64(2)
+	FpSub(&c.mapToC2, &c.mapToC1, &one)
+	FpDiv(&c.mapToC2, &c.mapToC2, &two)
+
+	bn, curveType = c, curve
+
+	return nil
+}
+
+// bnPolynomial returns a4 u^4 + a3 u^3 + a2 u^2 + a1 u + a0
+func bnPolynomial(u *big.Int, a4, a3, a2, a1, a0 int64) *big.Int {
+	res := big.NewInt(0)
+
+	for _, a := range []int64{a4, a3, a2, a1, a0} {
+		res.Mul(res, u)
+		res.Add(res, big.NewInt(a))
+	}
+
+	return res
+}
+
+// toNAF returns non adjacent form of positive x, least significant digit first
+func toNAF(x *big.Int) []int8 {
+	var res []int8
+
+	k := new(big.Int).Set(x)
+
+	for k.Sign() > 0 {
+		var d int8
+
+		if k.Bit(0) == 1 {
+			d = int8(2 - int(k.Bits()[0]&3))
+			k.Sub(k, big.NewInt(int64(d)))
+		}
+
+		res = append(res, d)
+		k.Rsh(k, 1)
+	}
+
+	return res
+}
+
+// GetFrUnitSize --
+func GetFrUnitSize() int {
+	return 4
+}
+
+// GetFpUnitSize --
+// same as GetMaxOpUnitSize()
+func GetFpUnitSize() int {
+	return 4
+}
+
+// GetMaxOpUnitSize --
+func GetMaxOpUnitSize() int {
+	return 4
+}
+
+// GetOpUnitSize --
+// the length of Fr is GetOpUnitSize() * 8 bytes
+func GetOpUnitSize() int {
+	return 4
+}
+
+// GetFrByteSize -- the serialized size of Fr
+func GetFrByteSize() int {
+	return 32
+}
+
+// GetFpByteSize -- the serialized size of Fp
+func GetFpByteSize() int {
+	return 32
+}
+
+// GetG1ByteSize -- the serialized size of G1
+func GetG1ByteSize() int {
+	return GetFpByteSize()
+}
+
+// GetG2ByteSize -- the serialized size of G2
+func GetG2ByteSize() int {
+	return GetFpByteSize() * 2
+}
+
+// GetCurveOrder --
+// return the order of G1
+func GetCurveOrder() string {
+	return bn.r.String()
+}
+
+// GetFieldOrder --
+// return the characteristic of the field where a curve is defined
+func GetFieldOrder() string {
+	return bn.p.String()
+}
+
+// VerifyOrderG1 -- verify order if SetString/Deserialize are called
+func VerifyOrderG1(doVerify bool) {
+	verifyOrderG1 = doVerify
+}
+
+// VerifyOrderG2 -- verify order if SetString/Deserialize are called
+func VerifyOrderG2(doVerify bool) {
+	verifyOrderG2 = doVerify
+}
+
+// SetETHserialization -- only the default mcl serialization is available in the pure Go backend
+func SetETHserialization(enable bool) {
+	if enable {
+		panic(fmt.Errorf("SetETHserialization: %w", errUnsupportedByPurego))
+	}
+}
+
+// SetMapToMode -- only the default mode 0 is available in the pure Go backend
+func SetMapToMode(mode int) error {
+	if mode != 0 {
+		return fmt.Errorf("SetMapToMode mode=%d: %w", mode, errUnsupportedByPurego)
+	}
+
+	return nil
+}
+
+// SetDstG1 --
+func SetDstG1(s string) error {
+	return fmt.Errorf("err mclBnG1_setDst: %w", errUnsupportedByPurego)
+}
+
+// SetDstG2 --
+func SetDstG2(s string) error {
+	return fmt.Errorf("err mclBnG2_setDst: %w", errUnsupportedByPurego)
+}
+
+// FrEvaluatePolynomial -- y = c[0] + c[1] * x + c[2] * x^2 + ...
+func FrEvaluatePolynomial(y *Fr, c []Fr, x *Fr) error {
+	n := len(c)
+	if n == 0 {
+		y.Clear()
+		return nil
+	}
+
+	res := c[n-1]
+
+	for i := n - 2; i >= 0; i-- {
+		FrMul(&res, &res, x)
+		FrAdd(&res, &res, &c[i])
+	}
+
+	*y = res
+
+	return nil
+}
+
+// G1EvaluatePolynomial -- y = c[0] + c[1] * x + c[2] * x^2 + ...
+func G1EvaluatePolynomial(y *G1, c []G1, x *Fr) error {
+	n := len(c)
+	if n == 0 {
+		y.Clear()
+		return nil
+	}
+
+	res := c[n-1]
+
+	for i := n - 2; i >= 0; i-- {
+		G1Mul(&res, &res, x)
+		G1Add(&res, &res, &c[i])
+	}
+
+	*y = res
+
+	return nil
+}
+
+// G2EvaluatePolynomial -- y = c[0] + c[1] * x + c[2] * x^2 + ...
+func G2EvaluatePolynomial(y *G2, c []G2, x *Fr) error {
+	n := len(c)
+	if n == 0 {
+		y.Clear()
+		return nil
+	}
+
+	res := c[n-1]
+
+	for i := n - 2; i >= 0; i-- {
+		G2Mul(&res, &res, x)
+		G2Add(&res, &res, &c[i])
+	}
+
+	*y = res
+
+	return nil
+}
+
+// lagrangeCoefficients returns L_i(0) = prod_{j != i} x_j / (x_j - x_i)
+func lagrangeCoefficients(xVec []Fr) ([]Fr, bool) {
+	var a Fr
+
+	a.SetInt64(1)
+
+	for i := range xVec {
+		if xVec[i].IsZero() {
+			return nil, false
+		}
+
+		FrMul(&a, &a, &xVec[i])
+	}
+
+	res := make([]Fr, len(xVec))
+
+	for i := range xVec {
+		b := xVec[i]
+
+		for j := range xVec {
+			if i != j {
+				var t Fr
+
+				FrSub(&t, &xVec[j], &xVec[i])
+				FrMul(&b, &b, &t)
+			}
+		}
+
+		if b.IsZero() {
+			return nil, false
+		}
+
+		FrDiv(&res[i], &a, &b)
+	}
+
+	return res, true
+}
+
+// FrLagrangeInterpolation --
+func FrLagrangeInterpolation(out *Fr, xVec []Fr, yVec []Fr) error {
+	n := len(xVec)
+	if n == 0 {
+		return fmt.Errorf("err FrLagrangeInterpolation:n=0")
+	}
+	if n != len(yVec) {
+		return fmt.Errorf("err FrLagrangeInterpolation:bad size")
+	}
+	if n == 1 {
+		*out = yVec[0]
+		return nil
+	}
+
+	coefs, ok := lagrangeCoefficients(xVec)
+	if !ok {
+		return fmt.Errorf("err FrLagrangeInterpolation")
+	}
+
+	var res, t Fr
+
+	for i := range coefs {
+		FrMul(&t, &coefs[i], &yVec[i])
+		FrAdd(&res, &res, &t)
+	}
+
+	*out = res
+
+	return nil
+}
+
+// G1LagrangeInterpolation --
+func G1LagrangeInterpolation(out *G1, xVec []Fr, yVec []G1) error {
+	n := len(xVec)
+	if n == 0 {
+		return fmt.Errorf("err G1LagrangeInterpolation:n=0")
+	}
+	if n != len(yVec) {
+		return fmt.Errorf("err G1LagrangeInterpolation:bad size")
+	}
+	if n == 1 {
+		*out = yVec[0]
+		return nil
+	}
+
+	coefs, ok := lagrangeCoefficients(xVec)
+	if !ok {
+		return fmt.Errorf("err G1LagrangeInterpolation")
+	}
+
+	G1MulVec(out, yVec, coefs)
+
+	return nil
+}
+
+// G2LagrangeInterpolation --
+func G2LagrangeInterpolation(out *G2, xVec []Fr, yVec []G2) error {
+	n := len(xVec)
+	if n == 0 {
+		return fmt.Errorf("err G2LagrangeInterpolation:n=0")
+	}
+	if n != len(yVec) {
+		return fmt.Errorf("err G2LagrangeInterpolation:bad size")
+	}
+	if n == 1 {
+		*out = yVec[0]
+		return nil
+	}
+
+	coefs, ok := lagrangeCoefficients(xVec)
+	if !ok {
+		return fmt.Errorf("err G2LagrangeInterpolation")
+	}
+
+	G2MulVec(out, yVec, coefs)
+
+	return nil
+}
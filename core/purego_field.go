@@ -0,0 +1,1032 @@
+//go:build purego
+
+package core
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// montField -- prime field with elements kept in Montgomery form x * 2^256 mod m
+// which is the same representation mcl uses for 254-bit curves
+type montField struct {
+	m      [4]uint64
+	inv    uint64 // -m^-1 mod 2^64
+	one    [4]uint64
+	r2     [4]uint64
+	bitLen int
+	// mod is m as big integer and half is (m + 1) / 2
+	mod  *big.Int
+	half *big.Int
+}
+
+func newMontField(mod *big.Int) *montField {
+	f := &montField{
+		mod:    new(big.Int).Set(mod),
+		half:   new(big.Int).Rsh(new(big.Int).Add(mod, big.NewInt(1)), 1),
+		bitLen: mod.BitLen(),
+	}
+
+	f.m = bigToLimbs(mod)
+
+	// Newton iteration for m^-1 mod 2^64
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - f.m[0]*inv
+	}
+
+	f.inv = -inv
+
+	r := new(big.Int).Lsh(big.NewInt(1), 256)
+	f.one = bigToLimbs(new(big.Int).Mod(r, mod))
+	f.r2 = bigToLimbs(new(big.Int).Mod(new(big.Int).Mul(r, r), mod))
+
+	return f
+}
+
+func bigToLimbs(x *big.Int) [4]uint64 {
+	var res [4]uint64
+
+	buf := make([]byte, 32)
+	x.FillBytes(buf)
+
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			res[i] |= uint64(buf[31-i*8-j]) << (8 * j)
+		}
+	}
+
+	return res
+}
+
+func limbsToBig(x *[4]uint64) *big.Int {
+	buf := make([]byte, 32)
+
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			buf[31-i*8-j] = byte(x[i] >> (8 * j))
+		}
+	}
+
+	return new(big.Int).SetBytes(buf)
+}
+
+func limbsIsZero(x *[4]uint64) bool {
+	return x[0]|x[1]|x[2]|x[3] == 0
+}
+
+// limbsGeq returns true if x >= y
+func limbsGeq(x, y *[4]uint64) bool {
+	for i := 3; i >= 0; i-- {
+		if x[i] != y[i] {
+			return x[i] > y[i]
+		}
+	}
+
+	return true
+}
+
+func (f *montField) add(z, x, y *[4]uint64) {
+	var c uint64
+
+	var t [4]uint64
+
+	t[0], c = bits.Add64(x[0], y[0], 0)
+	t[1], c = bits.Add64(x[1], y[1], c)
+	t[2], c = bits.Add64(x[2], y[2], c)
+	t[3], _ = bits.Add64(x[3], y[3], c)
+
+	f.reduceOnce(&t)
+	*z = t
+}
+
+func (f *montField) sub(z, x, y *[4]uint64) {
+	var b uint64
+
+	var t [4]uint64
+
+	t[0], b = bits.Sub64(x[0], y[0], 0)
+	t[1], b = bits.Sub64(x[1], y[1], b)
+	t[2], b = bits.Sub64(x[2], y[2], b)
+	t[3], b = bits.Sub64(x[3], y[3], b)
+
+	if b != 0 {
+		var c uint64
+
+		t[0], c = bits.Add64(t[0], f.m[0], 0)
+		t[1], c = bits.Add64(t[1], f.m[1], c)
+		t[2], c = bits.Add64(t[2], f.m[2], c)
+		t[3], _ = bits.Add64(t[3], f.m[3], c)
+	}
+
+	*z = t
+}
+
+func (f *montField) neg(z, x *[4]uint64) {
+	if limbsIsZero(x) {
+		*z = [4]uint64{}
+
+		return
+	}
+
+	var b uint64
+
+	z[0], b = bits.Sub64(f.m[0], x[0], 0)
+	z[1], b = bits.Sub64(f.m[1], x[1], b)
+	z[2], b = bits.Sub64(f.m[2], x[2], b)
+	z[3], _ = bits.Sub64(f.m[3], x[3], b)
+}
+
+// reduceOnce subtracts modulus if x >= m
+func (f *montField) reduceOnce(x *[4]uint64) {
+	if !limbsGeq(x, &f.m) {
+		return
+	}
+
+	var b uint64
+
+	x[0], b = bits.Sub64(x[0], f.m[0], 0)
+	x[1], b = bits.Sub64(x[1], f.m[1], b)
+	x[2], b = bits.Sub64(x[2], f.m[2], b)
+	x[3], _ = bits.Sub64(x[3], f.m[3], b)
+}
+
+// mul -- Montgomery multiplication z = x * y / 2^256 mod m (CIOS)
+func (f *montField) mul(z, x, y *[4]uint64) {
+	var t [6]uint64
+
+	for i := 0; i < 4; i++ {
+		var c uint64
+
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(x[j], y[i])
+
+			var cc uint64
+
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+
+		t[4], t[5] = bits.Add64(t[4], c, 0)
+
+		m := t[0] * f.inv
+		hi, lo := bits.Mul64(m, f.m[0])
+		_, cc := bits.Add64(lo, t[0], 0)
+		c = hi + cc
+
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(m, f.m[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+
+		t[3], cc = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + cc
+	}
+
+	res := [4]uint64{t[0], t[1], t[2], t[3]}
+	f.reduceOnce(&res)
+	*z = res
+}
+
+func (f *montField) toMont(z *[4]uint64, x *big.Int) {
+	v := bigToLimbs(x)
+	f.mul(z, &v, &f.r2)
+}
+
+func (f *montField) fromMont(x *[4]uint64) *big.Int {
+	one := [4]uint64{1}
+
+	var v [4]uint64
+
+	f.mul(&v, x, &one)
+
+	return limbsToBig(&v)
+}
+
+func (f *montField) exp(z, x *[4]uint64, e *big.Int) {
+	res := f.one
+	base := *x
+
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		f.mul(&res, &res, &res)
+
+		if e.Bit(i) == 1 {
+			f.mul(&res, &res, &base)
+		}
+	}
+
+	*z = res
+}
+
+func (f *montField) inverse(z, x *[4]uint64) {
+	if limbsIsZero(x) {
+		*z = [4]uint64{}
+
+		return
+	}
+
+	f.exp(z, x, new(big.Int).Sub(f.mod, big.NewInt(2)))
+}
+
+// batchInverse sets z[i] = 1 / x[i] with a single inversion (Montgomery's trick) for elements of
+// any field given by its operations, like GO_BATCH_INV of the mcl backend. Zero elements stay zero,
+// z may alias x
+func batchInverse[T any](z, x []T, isZero func(x *T) bool, mul func(z, x, y *T), inverse func(z, x *T)) {
+	var acc, t T
+
+	tmp := make([]T, len(x))
+	first := len(x)
+
+	for i := range x {
+		if isZero(&x[i]) {
+			continue
+		}
+
+		if first == len(x) {
+			first, acc = i, x[i]
+
+			continue
+		}
+
+		tmp[i] = acc
+		mul(&acc, &acc, &x[i])
+	}
+
+	if first < len(x) {
+		inverse(&acc, &acc)
+	}
+
+	for i := len(x) - 1; i >= 0; i-- {
+		switch {
+		case isZero(&x[i]):
+			z[i] = x[i]
+		case i == first:
+			z[i] = acc
+		default:
+			t = x[i]
+			mul(&z[i], &acc, &tmp[i])
+			mul(&acc, &acc, &t)
+		}
+	}
+}
+
+// setBig sets z = x mod m
+func (f *montField) setBig(z *[4]uint64, x *big.Int) {
+	f.toMont(z, new(big.Int).Mod(x, f.mod))
+}
+
+// setLittleEndianMask -- x = buf & (1 << bitLen(m)) - 1, if (x >= m) x &= (1 << (bitLen(m) - 1)) - 1
+func (f *montField) setLittleEndianMask(z *[4]uint64, buf []byte) {
+	if len(buf) > 32 {
+		buf = buf[:32]
+	}
+
+	x := new(big.Int).SetBytes(reverseBytes(buf))
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(f.bitLen)), big.NewInt(1))
+	x.And(x, mask)
+
+	if x.Cmp(f.mod) >= 0 {
+		x.And(x, new(big.Int).Rsh(mask, 1))
+	}
+
+	f.toMont(z, x)
+}
+
+// setString parses decimal or hexadecimal string. Values which are not less than m are rejected
+func (f *montField) setString(z *[4]uint64, s string, base int) error {
+	if base != 10 && base != 16 {
+		return fmt.Errorf("unsupported base %d", base)
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	if negative {
+		s = s[1:]
+	}
+
+	if base == 16 {
+		s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	}
+
+	x, ok := new(big.Int).SetString(s, base)
+	if !ok || x.Sign() < 0 || x.Cmp(f.mod) >= 0 {
+		return fmt.Errorf("invalid field element string %q", s)
+	}
+
+	if negative {
+		x.Sub(f.mod, x).Mod(x, f.mod)
+	}
+
+	f.toMont(z, x)
+
+	return nil
+}
+
+func (f *montField) getString(x *[4]uint64, base int) string {
+	if base != 10 && base != 16 {
+		panic(fmt.Sprintf("unsupported base %d", base))
+	}
+
+	return f.fromMont(x).Text(base)
+}
+
+// serialize returns 32 bytes little-endian canonical representation
+func (f *montField) serialize(x *[4]uint64) []byte {
+	buf := make([]byte, 32)
+	f.fromMont(x).FillBytes(buf)
+
+	return reverseBytes(buf)
+}
+
+// deserialize reads 32 bytes little-endian canonical representation
+func (f *montField) deserialize(z *[4]uint64, buf []byte) bool {
+	if len(buf) != 32 {
+		return false
+	}
+
+	x := new(big.Int).SetBytes(reverseBytes(buf))
+	if x.Cmp(f.mod) >= 0 {
+		return false
+	}
+
+	f.toMont(z, x)
+
+	return true
+}
+
+func (f *montField) setByCSPRNG(z *[4]uint64) error {
+	for {
+		x, err := rand.Int(rand.Reader, f.mod)
+		if err != nil {
+			return err
+		}
+
+		if x.Sign() != 0 {
+			f.toMont(z, x)
+
+			return nil
+		}
+	}
+}
+
+func (f *montField) setHashOf(z *[4]uint64, buf []byte) {
+	h := sha256.Sum256(buf)
+	f.setLittleEndianMask(z, h[:])
+}
+
+func (f *montField) isOdd(x *[4]uint64) bool {
+	return f.fromMont(x).Bit(0) == 1
+}
+
+func (f *montField) isNegative(x *[4]uint64) bool {
+	return f.fromMont(x).Cmp(f.half) >= 0
+}
+
+// legendre returns 1 if x is non zero square, -1 if x is not square and 0 for zero
+func (f *montField) legendre(x *[4]uint64) int {
+	return big.Jacobi(f.fromMont(x), f.mod)
+}
+
+// squareRoot uses Tonelli-Shanks algorithm. For m = 3 mod 4 it is y = x^((m + 1) / 4)
+func (f *montField) squareRoot(z, x *[4]uint64) bool {
+	if limbsIsZero(x) {
+		*z = [4]uint64{}
+
+		return true
+	}
+
+	if f.legendre(x) < 0 {
+		return false
+	}
+
+	// m - 1 = q * 2^s
+	q := new(big.Int).Sub(f.mod, big.NewInt(1))
+	s := 0
+
+	for q.Bit(0) == 0 {
+		q.Rsh(q, 1)
+		s++
+	}
+
+	var y [4]uint64
+
+	if s == 1 {
+		f.exp(&y, x, new(big.Int).Rsh(new(big.Int).Add(f.mod, big.NewInt(1)), 2))
+		*z = y
+
+		return true
+	}
+
+	// smallest quadratic non-residue
+	var nonResidue [4]uint64
+
+	for i := int64(2); ; i++ {
+		f.toMont(&nonResidue, big.NewInt(i))
+
+		if f.legendre(&nonResidue) < 0 {
+			break
+		}
+	}
+
+	var c, t, b [4]uint64
+
+	f.exp(&c, &nonResidue, q)
+	f.exp(&t, x, q)
+	f.exp(&y, x, new(big.Int).Rsh(new(big.Int).Add(q, big.NewInt(1)), 1))
+
+	for m := s; t != f.one; {
+		i := 1
+
+		t2 := t
+		f.mul(&t2, &t2, &t2)
+
+		for t2 != f.one {
+			f.mul(&t2, &t2, &t2)
+			i++
+		}
+
+		b = c
+		for j := 0; j < m-i-1; j++ {
+			f.mul(&b, &b, &b)
+		}
+
+		f.mul(&y, &y, &b)
+		f.mul(&c, &b, &b)
+		f.mul(&t, &t, &c)
+		m = i
+	}
+
+	*z = y
+
+	return true
+}
+
+// fpField and frField are initialized by InitCurve
+var fpField, frField *montField
+
+// Fr --
+type Fr struct {
+	v [4]uint64
+}
+
+// Clear --
+func (x *Fr) Clear() {
+	x.v = [4]uint64{}
+}
+
+// SetInt64 --
+func (x *Fr) SetInt64(v int64) {
+	frField.setBig(&x.v, big.NewInt(v))
+}
+
+// SetString --
+func (x *Fr) SetString(s string, base int) error {
+	if err := frField.setString(&x.v, s, base); err != nil {
+		return fmt.Errorf("err Fr.SetString %w", err)
+	}
+
+	return nil
+}
+
+// Deserialize --
+func (x *Fr) Deserialize(buf []byte) error {
+	if !frField.deserialize(&x.v, buf) {
+		return fmt.Errorf("err Fr.Deserialize %x", buf)
+	}
+
+	return nil
+}
+
+// SetLittleEndian --
+func (x *Fr) SetLittleEndian(buf []byte) error {
+	frField.setLittleEndianMask(&x.v, buf)
+
+	return nil
+}
+
+// SetLittleEndianMod --
+func (x *Fr) SetLittleEndianMod(buf []byte) error {
+	if len(buf) > 64 {
+		return fmt.Errorf("err Fr.SetLittleEndianMod size %d", len(buf))
+	}
+
+	frField.setBig(&x.v, new(big.Int).SetBytes(reverseBytes(buf)))
+
+	return nil
+}
+
+// SetBigEndianMod --
+func (x *Fr) SetBigEndianMod(buf []byte) error {
+	if len(buf) > 64 {
+		return fmt.Errorf("err Fr.SetBigEndianMod size %d", len(buf))
+	}
+
+	frField.setBig(&x.v, new(big.Int).SetBytes(buf))
+
+	return nil
+}
+
+// IsEqual --
+func (x *Fr) IsEqual(rhs *Fr) bool {
+	return x.v == rhs.v
+}
+
+// IsZero --
+func (x *Fr) IsZero() bool {
+	return limbsIsZero(&x.v)
+}
+
+// IsValid --
+func (x *Fr) IsValid() bool {
+	return !limbsGeq(&x.v, &frField.m)
+}
+
+// IsOne --
+func (x *Fr) IsOne() bool {
+	return x.v == frField.one
+}
+
+// IsOdd --
+func (x *Fr) IsOdd() bool {
+	return frField.isOdd(&x.v)
+}
+
+// IsNegative -- true if x >= (r + 1) / 2
+func (x *Fr) IsNegative() bool {
+	return frField.isNegative(&x.v)
+}
+
+// SetByCSPRNG --
+func (x *Fr) SetByCSPRNG() bool {
+	return frField.setByCSPRNG(&x.v) == nil
+}
+
+// SetHashOf --
+func (x *Fr) SetHashOf(buf []byte) bool {
+	frField.setHashOf(&x.v, buf)
+
+	return true
+}
+
+// GetString --
+func (x *Fr) GetString(base int) string {
+	return frField.getString(&x.v, base)
+}
+
+// Serialize --
+func (x *Fr) Serialize() []byte {
+	return frField.serialize(&x.v)
+}
+
+// FrNeg --
+func FrNeg(out *Fr, x *Fr) {
+	frField.neg(&out.v, &x.v)
+}
+
+// FrInv --
+func FrInv(out *Fr, x *Fr) {
+	frField.inverse(&out.v, &x.v)
+}
+
+// FrSqr --
+func FrSqr(out *Fr, x *Fr) {
+	frField.mul(&out.v, &x.v, &x.v)
+}
+
+// FrAdd --
+func FrAdd(out *Fr, x *Fr, y *Fr) {
+	frField.add(&out.v, &x.v, &y.v)
+}
+
+// FrSub --
+func FrSub(out *Fr, x *Fr, y *Fr) {
+	frField.sub(&out.v, &x.v, &y.v)
+}
+
+// FrMul --
+func FrMul(out *Fr, x *Fr, y *Fr) {
+	frField.mul(&out.v, &x.v, &y.v)
+}
+
+// FrAddVec -- out[i] = xVec[i] + yVec[i]
+func FrAddVec(out []Fr, xVec []Fr, yVec []Fr) {
+	n := checkVecSize(len(out), len(xVec), len(yVec))
+
+	for i := 0; i < n; i++ {
+		frField.add(&out[i].v, &xVec[i].v, &yVec[i].v)
+	}
+}
+
+// FrSubVec -- out[i] = xVec[i] - yVec[i]
+func FrSubVec(out []Fr, xVec []Fr, yVec []Fr) {
+	n := checkVecSize(len(out), len(xVec), len(yVec))
+
+	for i := 0; i < n; i++ {
+		frField.sub(&out[i].v, &xVec[i].v, &yVec[i].v)
+	}
+}
+
+// FrMulVec -- out[i] = xVec[i] * yVec[i]
+func FrMulVec(out []Fr, xVec []Fr, yVec []Fr) {
+	n := checkVecSize(len(out), len(xVec), len(yVec))
+
+	for i := 0; i < n; i++ {
+		frField.mul(&out[i].v, &xVec[i].v, &yVec[i].v)
+	}
+}
+
+// FrBatchInv -- out[i] = 1 / xVec[i] with a single inversion, zero elements stay zero
+func FrBatchInv(out []Fr, xVec []Fr) {
+	n := checkVecSize(len(out), len(xVec), len(xVec))
+
+	batchInverse(out[:n], xVec[:n], (*Fr).IsZero, FrMul, FrInv)
+}
+
+// FrDiv --
+func FrDiv(out *Fr, x *Fr, y *Fr) {
+	var inv [4]uint64
+
+	frField.inverse(&inv, &y.v)
+	frField.mul(&out.v, &x.v, &inv)
+}
+
+// FrSquareRoot --
+func FrSquareRoot(out *Fr, x *Fr) bool {
+	return frField.squareRoot(&out.v, &x.v)
+}
+
+// Fp --
+type Fp struct {
+	v [4]uint64
+}
+
+func newFp(a, b, c, d uint64) Fp {
+	return Fp{v: [4]uint64{a, b, c, d}}
+}
+
+// Clear --
+func (x *Fp) Clear() {
+	x.v = [4]uint64{}
+}
+
+// SetInt64 --
+func (x *Fp) SetInt64(v int64) {
+	fpField.setBig(&x.v, big.NewInt(v))
+}
+
+// SetString --
+func (x *Fp) SetString(s string, base int) error {
+	if err := fpField.setString(&x.v, s, base); err != nil {
+		return fmt.Errorf("err Fp.SetString %w", err)
+	}
+
+	return nil
+}
+
+// Deserialize --
+func (x *Fp) Deserialize(buf []byte) error {
+	if !fpField.deserialize(&x.v, buf) {
+		return fmt.Errorf("err Fp.Deserialize %x", buf)
+	}
+
+	return nil
+}
+
+// SetLittleEndian --
+func (x *Fp) SetLittleEndian(buf []byte) error {
+	fpField.setLittleEndianMask(&x.v, buf)
+
+	return nil
+}
+
+// SetLittleEndianMod --
+func (x *Fp) SetLittleEndianMod(buf []byte) error {
+	if len(buf) > 64 {
+		return fmt.Errorf("err Fp.SetLittleEndianMod size %d", len(buf))
+	}
+
+	fpField.setBig(&x.v, new(big.Int).SetBytes(reverseBytes(buf)))
+
+	return nil
+}
+
+// SetBigEndianMod --
+func (x *Fp) SetBigEndianMod(buf []byte) error {
+	if len(buf) > 64 {
+		return fmt.Errorf("err Fp.SetBigEndianMod size %d", len(buf))
+	}
+
+	fpField.setBig(&x.v, new(big.Int).SetBytes(buf))
+
+	return nil
+}
+
+// IsEqual --
+func (x *Fp) IsEqual(rhs *Fp) bool {
+	return x.v == rhs.v
+}
+
+// IsZero --
+func (x *Fp) IsZero() bool {
+	return limbsIsZero(&x.v)
+}
+
+// IsValid --
+func (x *Fp) IsValid() bool {
+	return !limbsGeq(&x.v, &fpField.m)
+}
+
+// IsOne --
+func (x *Fp) IsOne() bool {
+	return x.v == fpField.one
+}
+
+// IsOdd --
+func (x *Fp) IsOdd() bool {
+	return fpField.isOdd(&x.v)
+}
+
+// IsNegative -- true if x >= (p + 1) / 2
+func (x *Fp) IsNegative() bool {
+	return fpField.isNegative(&x.v)
+}
+
+// SetByCSPRNG --
+func (x *Fp) SetByCSPRNG() {
+	if err := fpField.setByCSPRNG(&x.v); err != nil {
+		panic("err Fp.SetByCSPRNG")
+	}
+}
+
+// SetHashOf --
+func (x *Fp) SetHashOf(buf []byte) bool {
+	fpField.setHashOf(&x.v, buf)
+
+	return true
+}
+
+// GetString --
+func (x *Fp) GetString(base int) string {
+	return fpField.getString(&x.v, base)
+}
+
+// Serialize --
+func (x *Fp) Serialize() []byte {
+	return fpField.serialize(&x.v)
+}
+
+// FpNeg --
+func FpNeg(out *Fp, x *Fp) {
+	fpField.neg(&out.v, &x.v)
+}
+
+// FpInv --
+func FpInv(out *Fp, x *Fp) {
+	fpField.inverse(&out.v, &x.v)
+}
+
+// FpBatchInv -- out[i] = 1 / xVec[i] with a single inversion, zero elements stay zero
+func FpBatchInv(out []Fp, xVec []Fp) {
+	n := checkVecSize(len(out), len(xVec), len(xVec))
+
+	batchInverse(out[:n], xVec[:n], (*Fp).IsZero, FpMul, FpInv)
+}
+
+// FpSqr --
+func FpSqr(out *Fp, x *Fp) {
+	fpField.mul(&out.v, &x.v, &x.v)
+}
+
+// FpAdd --
+func FpAdd(out *Fp, x *Fp, y *Fp) {
+	fpField.add(&out.v, &x.v, &y.v)
+}
+
+// FpSub --
+func FpSub(out *Fp, x *Fp, y *Fp) {
+	fpField.sub(&out.v, &x.v, &y.v)
+}
+
+// FpMul --
+func FpMul(out *Fp, x *Fp, y *Fp) {
+	fpField.mul(&out.v, &x.v, &y.v)
+}
+
+// FpDiv --
+func FpDiv(out *Fp, x *Fp, y *Fp) {
+	var inv [4]uint64
+
+	fpField.inverse(&inv, &y.v)
+	fpField.mul(&out.v, &x.v, &inv)
+}
+
+// FpSquareRoot --
+func FpSquareRoot(out *Fp, x *Fp) bool {
+	return fpField.squareRoot(&out.v, &x.v)
+}
+
+// Fp2 -- x = D[0] + D[1] i where i^2 = -1
+type Fp2 struct {
+	D [2]Fp
+}
+
+// Clear --
+func (x *Fp2) Clear() {
+	x.D[0].Clear()
+	x.D[1].Clear()
+}
+
+// Deserialize --
+func (x *Fp2) Deserialize(buf []byte) error {
+	if len(buf) != 64 || !fpField.deserialize(&x.D[0].v, buf[:32]) || !fpField.deserialize(&x.D[1].v, buf[32:]) {
+		return fmt.Errorf("err Fp2.Deserialize %x", buf)
+	}
+
+	return nil
+}
+
+// IsEqual --
+func (x *Fp2) IsEqual(rhs *Fp2) bool {
+	return *x == *rhs
+}
+
+// IsZero --
+func (x *Fp2) IsZero() bool {
+	return x.D[0].IsZero() && x.D[1].IsZero()
+}
+
+// IsOne --
+func (x *Fp2) IsOne() bool {
+	return x.D[0].IsOne() && x.D[1].IsZero()
+}
+
+// Serialize --
+func (x *Fp2) Serialize() []byte {
+	return append(x.D[0].Serialize(), x.D[1].Serialize()...)
+}
+
+// Fp2Neg --
+func Fp2Neg(out *Fp2, x *Fp2) {
+	FpNeg(&out.D[0], &x.D[0])
+	FpNeg(&out.D[1], &x.D[1])
+}
+
+// Fp2Inv --
+func Fp2Inv(out *Fp2, x *Fp2) {
+	// 1 / (a + bi) = (a - bi) / (a^2 + b^2)
+	var t0, t1 Fp
+
+	FpSqr(&t0, &x.D[0])
+	FpSqr(&t1, &x.D[1])
+	FpAdd(&t0, &t0, &t1)
+	FpInv(&t0, &t0)
+	FpMul(&out.D[0], &x.D[0], &t0)
+	FpMul(&out.D[1], &x.D[1], &t0)
+	FpNeg(&out.D[1], &out.D[1])
+}
+
+// Fp2Sqr --
+func Fp2Sqr(out *Fp2, x *Fp2) {
+	// (a + bi)^2 = (a + b)(a - b) + 2abi
+	var t0, t1, t2 Fp
+
+	FpAdd(&t0, &x.D[0], &x.D[1])
+	FpSub(&t1, &x.D[0], &x.D[1])
+	FpMul(&t2, &x.D[0], &x.D[1])
+	FpMul(&out.D[0], &t0, &t1)
+	FpAdd(&out.D[1], &t2, &t2)
+}
+
+// Fp2Add --
+func Fp2Add(out *Fp2, x *Fp2, y *Fp2) {
+	FpAdd(&out.D[0], &x.D[0], &y.D[0])
+	FpAdd(&out.D[1], &x.D[1], &y.D[1])
+}
+
+// Fp2Sub --
+func Fp2Sub(out *Fp2, x *Fp2, y *Fp2) {
+	FpSub(&out.D[0], &x.D[0], &y.D[0])
+	FpSub(&out.D[1], &x.D[1], &y.D[1])
+}
+
+// Fp2Mul --
+func Fp2Mul(out *Fp2, x *Fp2, y *Fp2) {
+	// (a + bi)(c + di) = ac - bd + ((a + b)(c + d) - ac - bd)i
+	var ac, bd, t0, t1 Fp
+
+	FpMul(&ac, &x.D[0], &y.D[0])
+	FpMul(&bd, &x.D[1], &y.D[1])
+	FpAdd(&t0, &x.D[0], &x.D[1])
+	FpAdd(&t1, &y.D[0], &y.D[1])
+	FpMul(&t0, &t0, &t1)
+	FpSub(&out.D[0], &ac, &bd)
+	FpSub(&t0, &t0, &ac)
+	FpSub(&out.D[1], &t0, &bd)
+}
+
+// Fp2Div --
+func Fp2Div(out *Fp2, x *Fp2, y *Fp2) {
+	var inv Fp2
+
+	Fp2Inv(&inv, y)
+	Fp2Mul(out, x, &inv)
+}
+
+// Fp2SquareRoot --
+func Fp2SquareRoot(out *Fp2, x *Fp2) bool {
+	var t1, t2 Fp
+
+	if x.D[1].IsZero() {
+		if FpSquareRoot(&t1, &x.D[0]) {
+			out.D[0] = t1
+			out.D[1].Clear()
+		} else {
+			FpNeg(&t2, &x.D[0])
+			FpSquareRoot(&t1, &t2)
+			out.D[0].Clear()
+			out.D[1] = t1
+		}
+
+		return true
+	}
+
+	// |x|^2 = a^2 + b^2
+	FpSqr(&t1, &x.D[0])
+	FpSqr(&t2, &x.D[1])
+	FpAdd(&t1, &t1, &t2)
+
+	if !FpSquareRoot(&t1, &t1) {
+		return false
+	}
+
+	var half Fp
+
+	half.SetInt64(2)
+	FpInv(&half, &half)
+
+	FpAdd(&t2, &x.D[0], &t1)
+	FpMul(&t2, &t2, &half)
+
+	if !FpSquareRoot(&t2, &t2) {
+		FpSub(&t2, &x.D[0], &t1)
+		FpMul(&t2, &t2, &half)
+		FpSquareRoot(&t2, &t2)
+	}
+
+	out.D[0] = t2
+	FpAdd(&t2, &t2, &t2)
+	FpInv(&t2, &t2)
+	FpMul(&out.D[1], &x.D[1], &t2)
+
+	return true
+}
+
+// fp2Conj -- out = a - bi
+func fp2Conj(out *Fp2, x *Fp2) {
+	out.D[0] = x.D[0]
+	FpNeg(&out.D[1], &x.D[1])
+}
+
+// fp2MulFp -- out = x * y where y is in Fp
+func fp2MulFp(out *Fp2, x *Fp2, y *Fp) {
+	FpMul(&out.D[0], &x.D[0], y)
+	FpMul(&out.D[1], &x.D[1], y)
+}
+
+// fp2Legendre returns legendre symbol of the norm a^2 + b^2
+func fp2Legendre(x *Fp2) int {
+	var t0, t1 Fp
+
+	FpSqr(&t0, &x.D[0])
+	FpSqr(&t1, &x.D[1])
+	FpAdd(&t0, &t0, &t1)
+
+	return fpField.legendre(&t0.v)
+}
+
+func fp2Exp(out *Fp2, x *Fp2, e *big.Int) {
+	var res Fp2
+
+	res.D[0].v = fpField.one
+	base := *x
+
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		Fp2Sqr(&res, &res)
+
+		if e.Bit(i) == 1 {
+			Fp2Mul(&res, &res, &base)
+		}
+	}
+
+	*out = res
+}
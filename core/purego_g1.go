@@ -0,0 +1,580 @@
+//go:build purego
+
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// G1 -- point in Jacobian coordinates (X / Z^2, Y / Z^3), Z = 0 for the point at infinity
+type G1 struct {
+	X Fp
+	Y Fp
+	Z Fp
+}
+
+// Clear --
+func (x *G1) Clear() {
+	*x = G1{}
+}
+
+// SetString -- accepts "0", "1 <x> <y>", "2 <x>" (even y) and "3 <x>" (odd y)
+func (x *G1) SetString(s string, base int) error {
+	var res G1
+
+	if err := res.setString(s, base); err != nil {
+		return fmt.Errorf("err mclBnG1_setStr %w", err)
+	}
+
+	if !res.IsValid() {
+		return fmt.Errorf("err mclBnG1_setStr invalid point %s", s)
+	}
+
+	*x = res
+
+	return nil
+}
+
+func (x *G1) setString(s string, base int) error {
+	parts := strings.Fields(s)
+	if len(parts) == 0 {
+		return fmt.Errorf("empty string")
+	}
+
+	switch {
+	case parts[0] == "0" && len(parts) == 1:
+		x.Clear()
+
+		return nil
+	case parts[0] == "1" && len(parts) == 3:
+		if err := x.X.SetString(parts[1], base); err != nil {
+			return err
+		}
+
+		if err := x.Y.SetString(parts[2], base); err != nil {
+			return err
+		}
+	case (parts[0] == "2" || parts[0] == "3") && len(parts) == 2:
+		if err := x.X.SetString(parts[1], base); err != nil {
+			return err
+		}
+
+		if !g1SetY(x, parts[0] == "3") {
+			return fmt.Errorf("x is not on the curve")
+		}
+	default:
+		return fmt.Errorf("invalid format %q", s)
+	}
+
+	x.Z.SetInt64(1)
+
+	return nil
+}
+
+// Deserialize -- 32 bytes x with the top bit set if y is odd, all zeros for the point at infinity
+func (x *G1) Deserialize(buf []byte) error {
+	if len(buf) != GetG1ByteSize() {
+		return fmt.Errorf("err mclBnG1_deserialize %x", buf)
+	}
+
+	if isAllZero(buf) {
+		x.Clear()
+
+		return nil
+	}
+
+	tmp := append([]byte(nil), buf...)
+	isOdd := tmp[len(tmp)-1]&0x80 != 0
+	tmp[len(tmp)-1] &= 0x7f
+
+	var res G1
+
+	if err := res.X.Deserialize(tmp); err != nil || !g1SetY(&res, isOdd) {
+		return fmt.Errorf("err mclBnG1_deserialize %x", buf)
+	}
+
+	res.Z.SetInt64(1)
+
+	if verifyOrderG1 && !res.IsValidOrder() {
+		return fmt.Errorf("err mclBnG1_deserialize %x", buf)
+	}
+
+	*x = res
+
+	return nil
+}
+
+// g1SetY sets y = sqrt(x^3 + b) with the requested parity
+func g1SetY(p *G1, isOdd bool) bool {
+	var y Fp
+
+	g1Rhs(&y, &p.X)
+
+	if !FpSquareRoot(&p.Y, &y) {
+		return false
+	}
+
+	if p.Y.IsOdd() != isOdd {
+		FpNeg(&p.Y, &p.Y)
+	}
+
+	return true
+}
+
+// g1Rhs -- out = x^3 + b
+func g1Rhs(out *Fp, x *Fp) {
+	var t Fp
+
+	FpSqr(&t, x)
+	FpMul(&t, &t, x)
+	FpAdd(out, &t, &bn.b)
+}
+
+const ZERO_HEADER = 1 << 6
+
+func isZeroFormat(buf []byte, n int) bool {
+	if len(buf) < n {
+		return false
+	}
+	if buf[0] != ZERO_HEADER {
+		return false
+	}
+	for i := 1; i < n; i++ {
+		if buf[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// DeserializeUncompressed -- x.Deserialize() + y.Deserialize()
+func (x *G1) DeserializeUncompressed(buf []byte) error {
+	size := GetFpByteSize()
+
+	if isZeroFormat(buf, size*2) {
+		x.Clear()
+
+		return nil
+	}
+
+	if len(buf) < size*2 {
+		return fmt.Errorf("err UncompressedDeserialize %x", buf)
+	}
+
+	if err := x.X.Deserialize(buf[:size]); err != nil {
+		return fmt.Errorf("err UncompressedDeserialize X %x", buf)
+	}
+
+	if err := x.Y.Deserialize(buf[size : size*2]); err != nil {
+		return fmt.Errorf("err UncompressedDeserialize Y %x", buf)
+	}
+
+	x.Z.SetInt64(1)
+
+	if !x.IsValid() {
+		return fmt.Errorf("err invalid point")
+	}
+
+	return nil
+}
+
+// IsEqual --
+func (x *G1) IsEqual(rhs *G1) bool {
+	if x.IsZero() || rhs.IsZero() {
+		return x.IsZero() && rhs.IsZero()
+	}
+
+	// X1 Z2^2 == X2 Z1^2 and Y1 Z2^3 == Y2 Z1^3
+	var z1z1, z2z2, t0, t1 Fp
+
+	FpSqr(&z1z1, &x.Z)
+	FpSqr(&z2z2, &rhs.Z)
+	FpMul(&t0, &x.X, &z2z2)
+	FpMul(&t1, &rhs.X, &z1z1)
+
+	if !t0.IsEqual(&t1) {
+		return false
+	}
+
+	FpMul(&t0, &x.Y, &z2z2)
+	FpMul(&t0, &t0, &rhs.Z)
+	FpMul(&t1, &rhs.Y, &z1z1)
+	FpMul(&t1, &t1, &x.Z)
+
+	return t0.IsEqual(&t1)
+}
+
+// IsZero --
+func (x *G1) IsZero() bool {
+	return x.Z.IsZero()
+}
+
+// IsValid -- point is on the curve (and has order r if VerifyOrderG1 is enabled)
+func (x *G1) IsValid() bool {
+	if x.IsZero() {
+		return true
+	}
+
+	// Y^2 = X^3 + b Z^6
+	var lhs, rhs, z6 Fp
+
+	FpSqr(&lhs, &x.Y)
+	FpSqr(&rhs, &x.X)
+	FpMul(&rhs, &rhs, &x.X)
+	FpSqr(&z6, &x.Z)
+	FpMul(&z6, &z6, &x.Z)
+	FpSqr(&z6, &z6)
+	FpMul(&z6, &z6, &bn.b)
+	FpAdd(&rhs, &rhs, &z6)
+
+	if !lhs.IsEqual(&rhs) {
+		return false
+	}
+
+	return !verifyOrderG1 || x.IsValidOrder()
+}
+
+// IsValidOrder --
+func (x *G1) IsValidOrder() bool {
+	var res G1
+
+	g1MulLimbs(&res, x, bigToLimbs(bn.r))
+
+	return res.IsZero()
+}
+
+// HashAndMapTo --
+func (x *G1) HashAndMapTo(buf []byte) error {
+	var t Fp
+
+	t.SetHashOf(buf)
+
+	if err := MapToG1(x, &t); err != nil {
+		return fmt.Errorf("err mclBnG1_hashAndMapTo %w", err)
+	}
+
+	return nil
+}
+
+// GetString --
+func (x *G1) GetString(base int) string {
+	if x.IsZero() {
+		return "0"
+	}
+
+	var nx G1
+
+	G1Normalize(&nx, x)
+
+	return fmt.Sprintf("1 %s %s", nx.X.GetString(base), nx.Y.GetString(base))
+}
+
+// Serialize --
+func (x *G1) Serialize() []byte {
+	if x.IsZero() {
+		return make([]byte, GetG1ByteSize())
+	}
+
+	var nx G1
+
+	G1Normalize(&nx, x)
+
+	buf := nx.X.Serialize()
+	if nx.Y.IsOdd() {
+		buf[len(buf)-1] |= 0x80
+	}
+
+	return buf
+}
+
+// SerializeUncompressed -- all zero array if x.IsZero()
+func (x *G1) SerializeUncompressed() []byte {
+	buf := make([]byte, GetG1ByteSize()*2)
+	if x.IsZero() {
+		buf[0] = ZERO_HEADER
+		return buf
+	}
+
+	var nx G1
+
+	G1Normalize(&nx, x)
+	copy(buf, nx.X.Serialize())
+	copy(buf[GetFpByteSize():], nx.Y.Serialize())
+
+	return buf
+}
+
+// G1Normalize --
+func G1Normalize(out *G1, x *G1) {
+	if x.IsZero() || x.Z.IsOne() {
+		*out = *x
+
+		return
+	}
+
+	var zInv, zInv2 Fp
+
+	FpInv(&zInv, &x.Z)
+	FpSqr(&zInv2, &zInv)
+	FpMul(&out.X, &x.X, &zInv2)
+	FpMul(&zInv2, &zInv2, &zInv)
+	FpMul(&out.Y, &x.Y, &zInv2)
+	out.Z.SetInt64(1)
+}
+
+// G1NormalizeVec -- out[i] = normalized xVec[i] with a single inversion
+func G1NormalizeVec(out []G1, xVec []G1) {
+	n := checkVecSize(len(out), len(xVec), len(xVec))
+	zInv := make([]Fp, n)
+
+	for i := range xVec {
+		zInv[i] = xVec[i].Z
+	}
+
+	FpBatchInv(zInv, zInv)
+
+	var t Fp
+
+	for i := range xVec {
+		if xVec[i].IsZero() {
+			out[i] = xVec[i]
+
+			continue
+		}
+
+		FpSqr(&t, &zInv[i])
+		FpMul(&out[i].X, &xVec[i].X, &t)
+		FpMul(&t, &t, &zInv[i])
+		FpMul(&out[i].Y, &xVec[i].Y, &t)
+		out[i].Z.SetInt64(1)
+	}
+}
+
+// G1Neg --
+func G1Neg(out *G1, x *G1) {
+	out.X = x.X
+	FpNeg(&out.Y, &x.Y)
+	out.Z = x.Z
+}
+
+// G1Dbl --
+func G1Dbl(out *G1, x *G1) {
+	if x.IsZero() {
+		out.Clear()
+
+		return
+	}
+
+	// dbl-2009-l
+	var a, b, c, d, e, f, t Fp
+
+	FpSqr(&a, &x.X)
+	FpSqr(&b, &x.Y)
+	FpSqr(&c, &b)
+	FpAdd(&d, &x.X, &b)
+	FpSqr(&d, &d)
+	FpSub(&d, &d, &a)
+	FpSub(&d, &d, &c)
+	FpAdd(&d, &d, &d)
+	FpAdd(&e, &a, &a)
+	FpAdd(&e, &e, &a)
+	FpSqr(&f, &e)
+
+	// Z3 = 2 Y1 Z1 is computed first because out may alias x
+	FpMul(&t, &x.Y, &x.Z)
+	FpAdd(&out.Z, &t, &t)
+
+	FpSub(&out.X, &f, &d)
+	FpSub(&out.X, &out.X, &d)
+
+	FpAdd(&c, &c, &c)
+	FpAdd(&c, &c, &c)
+	FpAdd(&c, &c, &c)
+	FpSub(&t, &d, &out.X)
+	FpMul(&t, &t, &e)
+	FpSub(&out.Y, &t, &c)
+}
+
+// G1Add --
+func G1Add(out *G1, x *G1, y *G1) {
+	if x.IsZero() {
+		*out = *y
+
+		return
+	}
+
+	if y.IsZero() {
+		*out = *x
+
+		return
+	}
+
+	// add-2007-bl
+	var z1z1, z2z2, u1, u2, s1, s2, h, r, hh, hhh, v, t Fp
+
+	FpSqr(&z1z1, &x.Z)
+	FpSqr(&z2z2, &y.Z)
+	FpMul(&u1, &x.X, &z2z2)
+	FpMul(&u2, &y.X, &z1z1)
+	FpMul(&s1, &x.Y, &y.Z)
+	FpMul(&s1, &s1, &z2z2)
+	FpMul(&s2, &y.Y, &x.Z)
+	FpMul(&s2, &s2, &z1z1)
+	FpSub(&h, &u2, &u1)
+	FpSub(&r, &s2, &s1)
+
+	if h.IsZero() {
+		if r.IsZero() {
+			G1Dbl(out, x)
+		} else {
+			out.Clear()
+		}
+
+		return
+	}
+
+	FpSqr(&hh, &h)
+	FpMul(&hhh, &h, &hh)
+	FpMul(&v, &u1, &hh)
+
+	FpMul(&t, &x.Z, &y.Z)
+	FpMul(&out.Z, &t, &h)
+
+	FpSqr(&out.X, &r)
+	FpSub(&out.X, &out.X, &hhh)
+	FpSub(&out.X, &out.X, &v)
+	FpSub(&out.X, &out.X, &v)
+
+	FpSub(&t, &v, &out.X)
+	FpMul(&t, &t, &r)
+	FpMul(&s1, &s1, &hhh)
+	FpSub(&out.Y, &t, &s1)
+}
+
+// G1Sub --
+func G1Sub(out *G1, x *G1, y *G1) {
+	var ny G1
+
+	G1Neg(&ny, y)
+	G1Add(out, x, &ny)
+}
+
+// G1Mul --
+func G1Mul(out *G1, x *G1, y *Fr) {
+	g1MulLimbs(out, x, bigToLimbs(frField.fromMont(&y.v)))
+}
+
+// g1MulLimbs -- out = x * k with 4-bit fixed window
+func g1MulLimbs(out *G1, x *G1, k [4]uint64) {
+	var table [16]G1
+
+	table[1] = *x
+	for i := 2; i < 16; i++ {
+		G1Add(&table[i], &table[i-1], x)
+	}
+
+	var res G1
+
+	for i := 63; i >= 0; i-- {
+		for j := 0; j < 4; j++ {
+			G1Dbl(&res, &res)
+		}
+
+		if d := (k[i/16] >> (4 * (i % 16))) & 0xf; d != 0 {
+			G1Add(&res, &res, &table[d])
+		}
+	}
+
+	*out = res
+}
+
+// G1MulVec -- multi scalar multiplication out = sum mul(xVec[i], yVec[i])
+func G1MulVec(out *G1, xVec []G1, yVec []Fr) {
+	n := len(xVec)
+	if n != len(yVec) {
+		panic("xVec and yVec have the same size")
+	}
+
+	var res, t G1
+
+	for i := range xVec {
+		G1Mul(&t, &xVec[i], &yVec[i])
+		G1Add(&res, &res, &t)
+	}
+
+	*out = res
+}
+
+// G1MulCT -- same as G1Mul in the pure Go backend
+func G1MulCT(out *G1, x *G1, y *Fr) {
+	G1Mul(out, x, y)
+}
+
+// MapToG1 -- maps field element to G1 point as mcl does for map to mode 0
+func MapToG1(out *G1, x *Fp) error {
+	if !g1CalcBN(out, x) {
+		return fmt.Errorf("err mclBnFp_mapToG1")
+	}
+
+	return nil
+}
+
+// g1CalcBN -- "Indifferentiable Hashing to Barreto-Naehrig Curves" by Fouque and Tibouchi, the same as mcl calcBN
+func g1CalcBN(out *G1, t *Fp) bool {
+	if t.IsZero() {
+		return false
+	}
+
+	negative := fpField.legendre(&t.v) < 0
+
+	var w, x, y, one Fp
+
+	one.SetInt64(1)
+
+	// w = c1 t / (t^2 + b + 1)
+	FpSqr(&w, t)
+	FpAdd(&w, &w, &bn.b)
+	FpAdd(&w, &w, &one)
+
+	if w.IsZero() {
+		return false
+	}
+
+	FpInv(&w, &w)
+	FpMul(&w, &w, &bn.mapToC1)
+	FpMul(&w, &w, t)
+
+	for i := 0; i < 3; i++ {
+		switch i {
+		case 0:
+			// x = c2 - t w
+			FpMul(&x, t, &w)
+			FpSub(&x, &bn.mapToC2, &x)
+		case 1:
+			// x = -1 - x
+			FpNeg(&x, &x)
+			FpSub(&x, &x, &one)
+		case 2:
+			// x = 1 + 1 / w^2
+			FpSqr(&x, &w)
+			FpInv(&x, &x)
+			FpAdd(&x, &x, &one)
+		}
+
+		g1Rhs(&y, &x)
+
+		if FpSquareRoot(&y, &y) {
+			if negative {
+				FpNeg(&y, &y)
+			}
+
+			out.X, out.Y = x, y
+			out.Z.SetInt64(1)
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,621 @@
+//go:build purego
+
+package core
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// G2 -- point in Jacobian coordinates (X / Z^2, Y / Z^3), Z = 0 for the point at infinity
+type G2 struct {
+	X Fp2
+	Y Fp2
+	Z Fp2
+}
+
+// Clear --
+func (x *G2) Clear() {
+	*x = G2{}
+}
+
+// SetString -- accepts "0", "1 <x0> <x1> <y0> <y1>", "2 <x0> <x1>" (even y0) and "3 <x0> <x1>" (odd y0)
+func (x *G2) SetString(s string, base int) error {
+	var res G2
+
+	if err := res.setString(s, base); err != nil {
+		return fmt.Errorf("err mclBnG2_setStr %w", err)
+	}
+
+	if !res.IsValid() {
+		return fmt.Errorf("err mclBnG2_setStr invalid point %s", s)
+	}
+
+	*x = res
+
+	return nil
+}
+
+func (x *G2) setString(s string, base int) error {
+	parts := strings.Fields(s)
+	if len(parts) == 0 {
+		return fmt.Errorf("empty string")
+	}
+
+	switch {
+	case parts[0] == "0" && len(parts) == 1:
+		x.Clear()
+
+		return nil
+	case parts[0] == "1" && len(parts) == 5:
+		if err := fp2SetStrings(&x.X, parts[1:3], base); err != nil {
+			return err
+		}
+
+		if err := fp2SetStrings(&x.Y, parts[3:5], base); err != nil {
+			return err
+		}
+	case (parts[0] == "2" || parts[0] == "3") && len(parts) == 3:
+		if err := fp2SetStrings(&x.X, parts[1:3], base); err != nil {
+			return err
+		}
+
+		if !g2SetY(x, parts[0] == "3") {
+			return fmt.Errorf("x is not on the curve")
+		}
+	default:
+		return fmt.Errorf("invalid format %q", s)
+	}
+
+	x.Z = fp2One()
+
+	return nil
+}
+
+// Deserialize -- 64 bytes x with the top bit set if real part of y is odd, all zeros for the point at infinity
+func (x *G2) Deserialize(buf []byte) error {
+	if len(buf) != GetG2ByteSize() {
+		return fmt.Errorf("err mclBnG2_deserialize %x", buf)
+	}
+
+	if isAllZero(buf) {
+		x.Clear()
+
+		return nil
+	}
+
+	tmp := append([]byte(nil), buf...)
+	isOdd := tmp[len(tmp)-1]&0x80 != 0
+	tmp[len(tmp)-1] &= 0x7f
+
+	var res G2
+
+	if err := res.X.Deserialize(tmp); err != nil || !g2SetY(&res, isOdd) {
+		return fmt.Errorf("err mclBnG2_deserialize %x", buf)
+	}
+
+	res.Z = fp2One()
+
+	if verifyOrderG2 && !res.IsValidOrder() {
+		return fmt.Errorf("err mclBnG2_deserialize %x", buf)
+	}
+
+	*x = res
+
+	return nil
+}
+
+// g2SetY sets y = sqrt(x^3 + b) with the requested parity of its real part
+func g2SetY(p *G2, isOdd bool) bool {
+	var y Fp2
+
+	g2Rhs(&y, &p.X)
+
+	if !Fp2SquareRoot(&p.Y, &y) {
+		return false
+	}
+
+	if p.Y.D[0].IsOdd() != isOdd {
+		Fp2Neg(&p.Y, &p.Y)
+	}
+
+	return true
+}
+
+// g2Rhs -- out = x^3 + b
+func g2Rhs(out *Fp2, x *Fp2) {
+	var t Fp2
+
+	Fp2Sqr(&t, x)
+	Fp2Mul(&t, &t, x)
+	Fp2Add(out, &t, &bn.bTwist)
+}
+
+// DeserializeUncompressed -- x.Deserialize() + y.Deserialize()
+func (x *G2) DeserializeUncompressed(buf []byte) error {
+	size := GetG2ByteSize()
+
+	if isZeroFormat(buf, size*2) {
+		x.Clear()
+
+		return nil
+	}
+
+	if len(buf) < size*2 {
+		return fmt.Errorf("err UncompressedDeserialize %x", buf)
+	}
+
+	if err := x.X.Deserialize(buf[:size]); err != nil {
+		return fmt.Errorf("err UncompressedDeserialize X %x", buf)
+	}
+
+	if err := x.Y.Deserialize(buf[size : size*2]); err != nil {
+		return fmt.Errorf("err UncompressedDeserialize Y %x", buf)
+	}
+
+	x.Z = fp2One()
+
+	if !x.IsValid() {
+		return fmt.Errorf("err invalid point")
+	}
+
+	return nil
+}
+
+// IsEqual --
+func (x *G2) IsEqual(rhs *G2) bool {
+	if x.IsZero() || rhs.IsZero() {
+		return x.IsZero() && rhs.IsZero()
+	}
+
+	// X1 Z2^2 == X2 Z1^2 and Y1 Z2^3 == Y2 Z1^3
+	var z1z1, z2z2, t0, t1 Fp2
+
+	Fp2Sqr(&z1z1, &x.Z)
+	Fp2Sqr(&z2z2, &rhs.Z)
+	Fp2Mul(&t0, &x.X, &z2z2)
+	Fp2Mul(&t1, &rhs.X, &z1z1)
+
+	if !t0.IsEqual(&t1) {
+		return false
+	}
+
+	Fp2Mul(&t0, &x.Y, &z2z2)
+	Fp2Mul(&t0, &t0, &rhs.Z)
+	Fp2Mul(&t1, &rhs.Y, &z1z1)
+	Fp2Mul(&t1, &t1, &x.Z)
+
+	return t0.IsEqual(&t1)
+}
+
+// IsZero --
+func (x *G2) IsZero() bool {
+	return x.Z.IsZero()
+}
+
+// IsValid -- point is on the curve (and has order r if VerifyOrderG2 is enabled)
+func (x *G2) IsValid() bool {
+	if x.IsZero() {
+		return true
+	}
+
+	// Y^2 = X^3 + b Z^6
+	var lhs, rhs, z6 Fp2
+
+	Fp2Sqr(&lhs, &x.Y)
+	Fp2Sqr(&rhs, &x.X)
+	Fp2Mul(&rhs, &rhs, &x.X)
+	Fp2Sqr(&z6, &x.Z)
+	Fp2Mul(&z6, &z6, &x.Z)
+	Fp2Sqr(&z6, &z6)
+	Fp2Mul(&z6, &z6, &bn.bTwist)
+	Fp2Add(&rhs, &rhs, &z6)
+
+	if !lhs.IsEqual(&rhs) {
+		return false
+	}
+
+	return !verifyOrderG2 || x.IsValidOrder()
+}
+
+// IsValidOrder --
+func (x *G2) IsValidOrder() bool {
+	var res G2
+
+	g2MulLimbs(&res, x, bigToLimbs(bn.r))
+
+	return res.IsZero()
+}
+
+// HashAndMapTo --
+func (x *G2) HashAndMapTo(buf []byte) error {
+	var t Fp2
+
+	t.D[0].SetHashOf(buf)
+
+	if err := MapToG2(x, &t); err != nil {
+		return fmt.Errorf("err mclBnG2_hashAndMapTo %w", err)
+	}
+
+	return nil
+}
+
+// GetString --
+func (x *G2) GetString(base int) string {
+	if x.IsZero() {
+		return "0"
+	}
+
+	var nx G2
+
+	G2Normalize(&nx, x)
+
+	return fmt.Sprintf("1 %s %s %s %s",
+		nx.X.D[0].GetString(base), nx.X.D[1].GetString(base), nx.Y.D[0].GetString(base), nx.Y.D[1].GetString(base))
+}
+
+// Serialize --
+func (x *G2) Serialize() []byte {
+	if x.IsZero() {
+		return make([]byte, GetG2ByteSize())
+	}
+
+	var nx G2
+
+	G2Normalize(&nx, x)
+
+	buf := nx.X.Serialize()
+	if nx.Y.D[0].IsOdd() {
+		buf[len(buf)-1] |= 0x80
+	}
+
+	return buf
+}
+
+// SerializeUncompressed -- all zero array if x.IsZero()
+func (x *G2) SerializeUncompressed() []byte {
+	buf := make([]byte, GetG2ByteSize()*2)
+	if x.IsZero() {
+		buf[0] = ZERO_HEADER
+		return buf
+	}
+
+	var nx G2
+
+	G2Normalize(&nx, x)
+	copy(buf, nx.X.Serialize())
+	copy(buf[GetG2ByteSize():], nx.Y.Serialize())
+
+	return buf
+}
+
+// G2Normalize --
+func G2Normalize(out *G2, x *G2) {
+	if x.IsZero() || x.Z.IsOne() {
+		*out = *x
+
+		return
+	}
+
+	var zInv, zInv2 Fp2
+
+	Fp2Inv(&zInv, &x.Z)
+	Fp2Sqr(&zInv2, &zInv)
+	Fp2Mul(&out.X, &x.X, &zInv2)
+	Fp2Mul(&zInv2, &zInv2, &zInv)
+	Fp2Mul(&out.Y, &x.Y, &zInv2)
+	out.Z = fp2One()
+}
+
+// G2NormalizeVec -- out[i] = normalized xVec[i] with a single inversion
+func G2NormalizeVec(out []G2, xVec []G2) {
+	n := checkVecSize(len(out), len(xVec), len(xVec))
+	zInv := make([]Fp2, n)
+
+	for i := range xVec {
+		zInv[i] = xVec[i].Z
+	}
+
+	batchInverse(zInv, zInv, (*Fp2).IsZero, Fp2Mul, Fp2Inv)
+
+	var t Fp2
+
+	for i := range xVec {
+		if xVec[i].IsZero() {
+			out[i] = xVec[i]
+
+			continue
+		}
+
+		Fp2Sqr(&t, &zInv[i])
+		Fp2Mul(&out[i].X, &xVec[i].X, &t)
+		Fp2Mul(&t, &t, &zInv[i])
+		Fp2Mul(&out[i].Y, &xVec[i].Y, &t)
+		out[i].Z = fp2One()
+	}
+}
+
+// G2Neg --
+func G2Neg(out *G2, x *G2) {
+	out.X = x.X
+	Fp2Neg(&out.Y, &x.Y)
+	out.Z = x.Z
+}
+
+// G2Dbl --
+func G2Dbl(out *G2, x *G2) {
+	if x.IsZero() {
+		out.Clear()
+
+		return
+	}
+
+	// dbl-2009-l
+	var a, b, c, d, e, f, t Fp2
+
+	Fp2Sqr(&a, &x.X)
+	Fp2Sqr(&b, &x.Y)
+	Fp2Sqr(&c, &b)
+	Fp2Add(&d, &x.X, &b)
+	Fp2Sqr(&d, &d)
+	Fp2Sub(&d, &d, &a)
+	Fp2Sub(&d, &d, &c)
+	Fp2Add(&d, &d, &d)
+	Fp2Add(&e, &a, &a)
+	Fp2Add(&e, &e, &a)
+	Fp2Sqr(&f, &e)
+
+	// Z3 = 2 Y1 Z1 is computed first because out may alias x
+	Fp2Mul(&t, &x.Y, &x.Z)
+	Fp2Add(&out.Z, &t, &t)
+
+	Fp2Sub(&out.X, &f, &d)
+	Fp2Sub(&out.X, &out.X, &d)
+
+	Fp2Add(&c, &c, &c)
+	Fp2Add(&c, &c, &c)
+	Fp2Add(&c, &c, &c)
+	Fp2Sub(&t, &d, &out.X)
+	Fp2Mul(&t, &t, &e)
+	Fp2Sub(&out.Y, &t, &c)
+}
+
+// G2Add --
+func G2Add(out *G2, x *G2, y *G2) {
+	if x.IsZero() {
+		*out = *y
+
+		return
+	}
+
+	if y.IsZero() {
+		*out = *x
+
+		return
+	}
+
+	// add-2007-bl
+	var z1z1, z2z2, u1, u2, s1, s2, h, r, hh, hhh, v, t Fp2
+
+	Fp2Sqr(&z1z1, &x.Z)
+	Fp2Sqr(&z2z2, &y.Z)
+	Fp2Mul(&u1, &x.X, &z2z2)
+	Fp2Mul(&u2, &y.X, &z1z1)
+	Fp2Mul(&s1, &x.Y, &y.Z)
+	Fp2Mul(&s1, &s1, &z2z2)
+	Fp2Mul(&s2, &y.Y, &x.Z)
+	Fp2Mul(&s2, &s2, &z1z1)
+	Fp2Sub(&h, &u2, &u1)
+	Fp2Sub(&r, &s2, &s1)
+
+	if h.IsZero() {
+		if r.IsZero() {
+			G2Dbl(out, x)
+		} else {
+			out.Clear()
+		}
+
+		return
+	}
+
+	Fp2Sqr(&hh, &h)
+	Fp2Mul(&hhh, &h, &hh)
+	Fp2Mul(&v, &u1, &hh)
+
+	Fp2Mul(&t, &x.Z, &y.Z)
+	Fp2Mul(&out.Z, &t, &h)
+
+	Fp2Sqr(&out.X, &r)
+	Fp2Sub(&out.X, &out.X, &hhh)
+	Fp2Sub(&out.X, &out.X, &v)
+	Fp2Sub(&out.X, &out.X, &v)
+
+	Fp2Sub(&t, &v, &out.X)
+	Fp2Mul(&t, &t, &r)
+	Fp2Mul(&s1, &s1, &hhh)
+	Fp2Sub(&out.Y, &t, &s1)
+}
+
+// G2Sub --
+func G2Sub(out *G2, x *G2, y *G2) {
+	var ny G2
+
+	G2Neg(&ny, y)
+	G2Add(out, x, &ny)
+}
+
+// G2Mul --
+func G2Mul(out *G2, x *G2, y *Fr) {
+	g2MulLimbs(out, x, bigToLimbs(frField.fromMont(&y.v)))
+}
+
+// g2MulLimbs -- out = x * k with 4-bit fixed window
+func g2MulLimbs(out *G2, x *G2, k [4]uint64) {
+	var table [16]G2
+
+	table[1] = *x
+	for i := 2; i < 16; i++ {
+		G2Add(&table[i], &table[i-1], x)
+	}
+
+	var res G2
+
+	for i := 63; i >= 0; i-- {
+		for j := 0; j < 4; j++ {
+			G2Dbl(&res, &res)
+		}
+
+		if d := (k[i/16] >> (4 * (i % 16))) & 0xf; d != 0 {
+			G2Add(&res, &res, &table[d])
+		}
+	}
+
+	*out = res
+}
+
+// G2MulVec -- multi scalar multiplication out = sum mul(xVec[i], yVec[i])
+func G2MulVec(out *G2, xVec []G2, yVec []Fr) {
+	n := len(xVec)
+	if n != len(yVec) {
+		panic("xVec and yVec have the same size")
+	}
+
+	var res, t G2
+
+	for i := range xVec {
+		G2Mul(&t, &xVec[i], &yVec[i])
+		G2Add(&res, &res, &t)
+	}
+
+	*out = res
+}
+
+// MapToG2 -- maps field element to G2 point as mcl does for map to mode 0
+func MapToG2(out *G2, x *Fp2) error {
+	var p G2
+
+	if !g2CalcBN(&p, x) {
+		return fmt.Errorf("err mclBnFp2_mapToG2")
+	}
+
+	g2MulByCofactor(out, &p)
+
+	return nil
+}
+
+// g2CalcBN -- the same as g1CalcBN for the twist
+func g2CalcBN(out *G2, t *Fp2) bool {
+	if t.IsZero() {
+		return false
+	}
+
+	negative := fp2Legendre(t) < 0
+
+	var w, x, y Fp2
+
+	one := fp2One()
+
+	// w = c1 t / (t^2 + b + 1)
+	Fp2Sqr(&w, t)
+	Fp2Add(&w, &w, &bn.bTwist)
+	Fp2Add(&w, &w, &one)
+
+	if w.IsZero() {
+		return false
+	}
+
+	Fp2Inv(&w, &w)
+	fp2MulFp(&w, &w, &bn.mapToC1)
+	Fp2Mul(&w, &w, t)
+
+	for i := 0; i < 3; i++ {
+		switch i {
+		case 0:
+			// x = c2 - t w
+			Fp2Mul(&x, t, &w)
+			Fp2Neg(&x, &x)
+			FpAdd(&x.D[0], &x.D[0], &bn.mapToC2)
+		case 1:
+			// x = -1 - x
+			Fp2Neg(&x, &x)
+			Fp2Sub(&x, &x, &one)
+		case 2:
+			// x = 1 + 1 / w^2
+			Fp2Sqr(&x, &w)
+			Fp2Inv(&x, &x)
+			Fp2Add(&x, &x, &one)
+		}
+
+		g2Rhs(&y, &x)
+
+		if Fp2SquareRoot(&y, &y) {
+			if negative {
+				Fp2Neg(&y, &y)
+			}
+
+			out.X, out.Y = x, y
+			out.Z = one
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// g2MulByCofactor -- out = [u]P + [3u]pi(P) + pi^2([u]P) + pi^3(P)
+// from "Faster Hashing to G2" by Fuentes-Castaneda, Knapp and Rodriguez-Henriquez
+func g2MulByCofactor(out *G2, p *G2) {
+	var t0, t1, t2 G2
+
+	g2MulBigInt(&t0, p, bn.u)
+	G2Dbl(&t1, &t0)
+	G2Add(&t1, &t1, &t0)
+	g2Frobenius(&t1, &t1)
+	g2Frobenius(&t2, &t0)
+	g2Frobenius(&t2, &t2)
+	G2Add(&t0, &t0, &t1)
+	G2Add(&t0, &t0, &t2)
+	g2Frobenius(&t2, p)
+	g2Frobenius(&t2, &t2)
+	g2Frobenius(&t2, &t2)
+	G2Add(out, &t0, &t2)
+}
+
+// g2MulBigInt -- out = x * k for signed k with |k| < 2^256
+func g2MulBigInt(out *G2, x *G2, k *big.Int) {
+	g2MulLimbs(out, x, bigToLimbs(new(big.Int).Abs(k)))
+
+	if k.Sign() < 0 {
+		G2Neg(out, out)
+	}
+}
+
+// g2Frobenius -- out = psi(x) = (conj(X) xi^((p - 1) / 3), conj(Y) xi^((p - 1) / 2), conj(Z))
+// which is the p-power Frobenius endomorphism carried over to the twist
+func g2Frobenius(out *G2, x *G2) {
+	fp2Conj(&out.X, &x.X)
+	fp2Conj(&out.Y, &x.Y)
+	fp2Conj(&out.Z, &x.Z)
+	Fp2Mul(&out.X, &out.X, &bn.frobeniusCoef[2])
+	Fp2Mul(&out.Y, &out.Y, &bn.frobeniusCoef[3])
+}
+
+func fp2One() Fp2 {
+	var res Fp2
+
+	res.D[0].SetInt64(1)
+
+	return res
+}
+
+func fp2SetStrings(x *Fp2, parts []string, base int) error {
+	for i := range x.D {
+		if err := x.D[i].SetString(parts[i], base); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,351 @@
+//go:build purego
+
+package core
+
+// Optimal ate pairing for BN curves with D-type twist. The Miller loop works on affine
+// points and multiplies by sparse lines l(P) = yP - lambda xP w + (lambda xT - yT) w^3,
+// so a line is fully described by the pair (lambda, lambda xT - yT) which depends on Q only.
+// Vertical lines lie in Fp6 and are replaced by 1 as they vanish after the final exponentiation.
+// Miller loop values are therefore equal to those of mcl only up to the final exponentiation
+
+// g2Line -- line coefficients, zero line stands for the vertical line
+type g2Line struct {
+	lambda, mu Fp2
+}
+
+// g2Affine -- affine point on the twist
+type g2Affine struct {
+	x, y   Fp2
+	isZero bool
+}
+
+// dbl returns tangent line at t and sets t = 2t
+func (t *g2Affine) dbl() g2Line {
+	if t.isZero || t.y.IsZero() {
+		t.isZero = true
+
+		return g2Line{}
+	}
+
+	var l g2Line
+
+	var num, den, x3 Fp2
+
+	// lambda = 3 x^2 / 2 y
+	Fp2Sqr(&num, &t.x)
+	Fp2Add(&den, &num, &num)
+	Fp2Add(&num, &den, &num)
+	Fp2Add(&den, &t.y, &t.y)
+	Fp2Div(&l.lambda, &num, &den)
+
+	t.addLine(&l, &t.x, &x3)
+
+	return l
+}
+
+// add returns line through t and q and sets t = t + q
+func (t *g2Affine) add(q *g2Affine) g2Line {
+	if q.isZero {
+		return g2Line{}
+	}
+
+	if t.isZero {
+		*t = *q
+
+		return g2Line{}
+	}
+
+	var l g2Line
+
+	var num, den, x3 Fp2
+
+	Fp2Sub(&den, &q.x, &t.x)
+
+	if den.IsZero() {
+		if t.y.IsEqual(&q.y) {
+			return t.dbl()
+		}
+
+		t.isZero = true
+
+		return g2Line{}
+	}
+
+	// lambda = (yQ - yT) / (xQ - xT)
+	Fp2Sub(&num, &q.y, &t.y)
+	Fp2Div(&l.lambda, &num, &den)
+
+	t.addLine(&l, &q.x, &x3)
+
+	return l
+}
+
+// addLine fills mu = lambda xT - yT and sets t to the third intersection point
+// x3 = lambda^2 - xT - x2, y3 = lambda (xT - x3) - yT
+func (t *g2Affine) addLine(l *g2Line, x2, x3 *Fp2) {
+	Fp2Mul(&l.mu, &l.lambda, &t.x)
+	Fp2Sub(&l.mu, &l.mu, &t.y)
+
+	Fp2Sqr(x3, &l.lambda)
+	Fp2Sub(x3, x3, &t.x)
+	Fp2Sub(x3, x3, x2)
+
+	var y3 Fp2
+
+	Fp2Sub(&y3, &t.x, x3)
+	Fp2Mul(&y3, &y3, &l.lambda)
+	Fp2Sub(&t.y, &y3, &t.y)
+	t.x = *x3
+}
+
+func g2ToAffine(q *G2) g2Affine {
+	if q.IsZero() {
+		return g2Affine{isZero: true}
+	}
+
+	var nq G2
+
+	G2Normalize(&nq, q)
+
+	return g2Affine{x: nq.X, y: nq.Y}
+}
+
+// g2MillerLines returns lines of the Miller loop for Q in the order they are used
+func g2MillerLines(q *G2) []g2Line {
+	naf := bn.loopNAF
+	lines := make([]g2Line, 0, g2MillerLinesCount())
+
+	qa := g2ToAffine(q)
+	nqa := qa
+	Fp2Neg(&nqa.y, &nqa.y)
+	t := qa
+
+	for i := len(naf) - 2; i >= 0; i-- {
+		lines = append(lines, t.dbl())
+
+		switch naf[i] {
+		case 1:
+			lines = append(lines, t.add(&qa))
+		case -1:
+			lines = append(lines, t.add(&nqa))
+		}
+	}
+
+	if bn.u.Sign() < 0 {
+		Fp2Neg(&t.y, &t.y)
+	}
+
+	// q1 = pi(Q), q2 = -pi^2(Q)
+	var q1, q2 G2
+
+	g2Frobenius(&q1, q)
+	g2Frobenius(&q2, &q1)
+	G2Neg(&q2, &q2)
+
+	q1a, q2a := g2ToAffine(&q1), g2ToAffine(&q2)
+
+	lines = append(lines, t.add(&q1a))
+	lines = append(lines, t.add(&q2a))
+
+	return lines
+}
+
+func g2MillerLinesCount() int {
+	naf := bn.loopNAF
+	n := len(naf) - 1 + 2
+
+	for i := len(naf) - 2; i >= 0; i-- {
+		if naf[i] != 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+// mulLine -- f = f * l(P) where P is normalized and not zero
+func mulLine(f *fp12, l *g2Line, p *G1) {
+	var t fp12
+
+	t.a.a.D[0] = p.Y
+	fp2MulFp(&t.b.a, &l.lambda, &p.X)
+	Fp2Neg(&t.b.a, &t.b.a)
+	t.b.b = l.mu
+
+	fp12Mul(f, f, &t)
+}
+
+// millerLoopLines -- f = prod_i f_{Q_i}(P_i) for precomputed lines of Q_i
+func millerLoopLines(out *GT, ps []G1, lines [][]g2Line) {
+	var f fp12
+
+	f.setOne()
+
+	nps := make([]G1, 0, len(ps))
+	nlines := make([][]g2Line, 0, len(lines))
+
+	for i := range ps {
+		if ps[i].IsZero() {
+			continue
+		}
+
+		var np G1
+
+		G1Normalize(&np, &ps[i])
+		nps = append(nps, np)
+		nlines = append(nlines, lines[i])
+	}
+
+	naf := bn.loopNAF
+	pos := 0
+
+	for i := len(naf) - 2; i >= 0; i-- {
+		fp12Mul(&f, &f, &f)
+
+		for j := range nps {
+			mulLine(&f, &nlines[j][pos], &nps[j])
+		}
+
+		pos++
+
+		if naf[i] != 0 {
+			for j := range nps {
+				mulLine(&f, &nlines[j][pos], &nps[j])
+			}
+
+			pos++
+		}
+	}
+
+	if bn.u.Sign() < 0 {
+		fp12Conj(&f, &f)
+	}
+
+	for ; pos < g2MillerLinesCount(); pos++ {
+		for j := range nps {
+			mulLine(&f, &nlines[j][pos], &nps[j])
+		}
+	}
+
+	out.v = f
+}
+
+// Pairing --
+func Pairing(out *GT, x *G1, y *G2) {
+	MillerLoop(out, x, y)
+	FinalExp(out, out)
+}
+
+// FinalExp -- the same power of x^((p^12 - 1) / r) as mcl
+func FinalExp(out *GT, x *GT) {
+	var f, t fp12
+
+	// easy part: x^((p^6 - 1)(p^2 + 1))
+	fp12Inv(&t, &x.v)
+	fp12Conj(&f, &x.v)
+	fp12Mul(&f, &f, &t)
+	fp12Frobenius(&t, &f)
+	fp12Frobenius(&t, &t)
+	fp12Mul(&f, &f, &t)
+
+	// hard part
+	fp12Exp(&out.v, &f, bn.hardPart)
+}
+
+// MillerLoop --
+func MillerLoop(out *GT, x *G1, y *G2) {
+	millerLoopLines(out, []G1{*x}, [][]g2Line{g2MillerLines(y)})
+}
+
+// MillerLoopVec -- multi pairings ; out = prod_i e(xVec[i], yVec[i])
+func MillerLoopVec(out *GT, xVec []G1, yVec []G2) {
+	n := len(xVec)
+	if n != len(yVec) {
+		panic("xVec and yVec have the same size")
+	}
+
+	lines := make([][]g2Line, n)
+	for i := range yVec {
+		lines[i] = g2MillerLines(&yVec[i])
+	}
+
+	millerLoopLines(out, xVec, lines)
+}
+
+// GetUint64NumToPrecompute --
+func GetUint64NumToPrecompute() int {
+	return g2MillerLinesCount() * 4 * GetFpUnitSize()
+}
+
+// PrecomputeG2 --
+func PrecomputeG2(Q *G2) []uint64 {
+	lines := g2MillerLines(Q)
+	Qbuf := make([]uint64, 0, GetUint64NumToPrecompute())
+
+	for i := range lines {
+		for _, x := range []*Fp2{&lines[i].lambda, &lines[i].mu} {
+			Qbuf = append(Qbuf, x.D[0].v[:]...)
+			Qbuf = append(Qbuf, x.D[1].v[:]...)
+		}
+	}
+
+	return Qbuf
+}
+
+func linesFromPrecomputed(Qbuf []uint64) []g2Line {
+	lines := make([]g2Line, g2MillerLinesCount())
+
+	for i := range lines {
+		for j, x := range []*Fp2{&lines[i].lambda, &lines[i].mu} {
+			offset := i*16 + j*8
+			copy(x.D[0].v[:], Qbuf[offset:offset+4])
+			copy(x.D[1].v[:], Qbuf[offset+4:offset+8])
+		}
+	}
+
+	return lines
+}
+
+// PrecomputedMillerLoop --
+func PrecomputedMillerLoop(out *GT, P *G1, Qbuf []uint64) {
+	millerLoopLines(out, []G1{*P}, [][]g2Line{linesFromPrecomputed(Qbuf)})
+}
+
+// PrecomputedMillerLoop2 --
+func PrecomputedMillerLoop2(out *GT, P1 *G1, Q1buf []uint64, P2 *G1, Q2buf []uint64) {
+	millerLoopLines(out, []G1{*P1, *P2}, [][]g2Line{linesFromPrecomputed(Q1buf), linesFromPrecomputed(Q2buf)})
+}
+
+// blsVerify checks e(signature, G2) e(-H, publicKey) == 1. H is messagePoint if it is not nil,
+// otherwise it is the HashToG107 point of 96 uniform bytes of expand_message_xmd
+func blsVerify(signature *G1, publicKey *G2, messagePoint *G1, uniform []byte) bool {
+	var (
+		p, q   G1
+		u      Fp
+		e1, e2 GT
+	)
+
+	if messagePoint == nil {
+		for i, r := range []*G1{&p, &q} {
+			if err := u.SetBigEndianMod(uniform[i*48 : (i+1)*48]); err != nil {
+				return false
+			}
+
+			if err := MapToG1(r, &u); err != nil {
+				return false
+			}
+		}
+
+		G1Add(&p, &p, &q)
+	} else {
+		p = *messagePoint
+	}
+
+	G1Neg(&p, &p)
+	PrecomputedMillerLoop(&e1, signature, GetCoef())
+	MillerLoop(&e2, &p, publicKey)
+	GTMul(&e1, &e1, &e2)
+	FinalExp(&e1, &e1)
+
+	return e1.IsOne()
+}
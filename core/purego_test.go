@@ -0,0 +1,61 @@
+//go:build purego
+
+package core
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PuregoFieldArithmetic(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range []*montField{fpField, frField} {
+		for i := 0; i < 100; i++ {
+			a, err := rand.Int(rand.Reader, f.mod)
+			require.NoError(t, err)
+
+			b, err := rand.Int(rand.Reader, f.mod)
+			require.NoError(t, err)
+
+			var x, y, z [4]uint64
+
+			f.toMont(&x, a)
+			f.toMont(&y, b)
+
+			f.add(&z, &x, &y)
+			assert.Equal(t, new(big.Int).Mod(new(big.Int).Add(a, b), f.mod), f.fromMont(&z))
+
+			f.sub(&z, &x, &y)
+			assert.Equal(t, new(big.Int).Mod(new(big.Int).Sub(a, b), f.mod), f.fromMont(&z))
+
+			f.mul(&z, &x, &y)
+			assert.Equal(t, new(big.Int).Mod(new(big.Int).Mul(a, b), f.mod), f.fromMont(&z))
+
+			f.inverse(&z, &x)
+			assert.Equal(t, new(big.Int).ModInverse(a, f.mod), f.fromMont(&z))
+		}
+	}
+}
+
+func Test_PuregoGTInverse(t *testing.T) {
+	t.Parallel()
+
+	g1 := testHashToG1(t)
+
+	var e, inv, one GT
+
+	Pairing(&e, g1, ellipticCurveG2)
+
+	// conjugate is the inverse in GT and generic inverse agrees with it
+	GTInv(&inv, &e)
+	GTMul(&one, &e, &inv)
+	assert.True(t, one.IsOne())
+
+	GTDiv(&one, &e, &e)
+	assert.True(t, one.IsOne())
+}
@@ -0,0 +1,380 @@
+//go:build purego
+
+package core
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// fp6 -- a + b v + c v^2 where v^3 = xi
+type fp6 struct {
+	a, b, c Fp2
+}
+
+// fp12 -- a + b w where w^2 = v
+type fp12 struct {
+	a, b fp6
+}
+
+// fp2MulXi -- out = x * xi where xi = xiA + i
+func fp2MulXi(out *Fp2, x *Fp2) {
+	var t0, t1 Fp
+
+	FpMul(&t0, &x.D[0], &bn.xiA)
+	FpSub(&t0, &t0, &x.D[1])
+	FpMul(&t1, &x.D[1], &bn.xiA)
+	FpAdd(&out.D[1], &t1, &x.D[0])
+	out.D[0] = t0
+}
+
+func fp6Add(out, x, y *fp6) {
+	Fp2Add(&out.a, &x.a, &y.a)
+	Fp2Add(&out.b, &x.b, &y.b)
+	Fp2Add(&out.c, &x.c, &y.c)
+}
+
+func fp6Sub(out, x, y *fp6) {
+	Fp2Sub(&out.a, &x.a, &y.a)
+	Fp2Sub(&out.b, &x.b, &y.b)
+	Fp2Sub(&out.c, &x.c, &y.c)
+}
+
+func fp6Neg(out, x *fp6) {
+	Fp2Neg(&out.a, &x.a)
+	Fp2Neg(&out.b, &x.b)
+	Fp2Neg(&out.c, &x.c)
+}
+
+func fp6Mul(out, x, y *fp6) {
+	var a0b0, a1b1, a2b2, t0, t1 Fp2
+
+	var res fp6
+
+	Fp2Mul(&a0b0, &x.a, &y.a)
+	Fp2Mul(&a1b1, &x.b, &y.b)
+	Fp2Mul(&a2b2, &x.c, &y.c)
+
+	// c0 = a0 b0 + xi (a1 b2 + a2 b1)
+	Fp2Mul(&t0, &x.b, &y.c)
+	Fp2Mul(&t1, &x.c, &y.b)
+	Fp2Add(&t0, &t0, &t1)
+	fp2MulXi(&t0, &t0)
+	Fp2Add(&res.a, &a0b0, &t0)
+
+	// c1 = a0 b1 + a1 b0 + xi a2 b2
+	Fp2Mul(&t0, &x.a, &y.b)
+	Fp2Mul(&t1, &x.b, &y.a)
+	Fp2Add(&t0, &t0, &t1)
+	fp2MulXi(&t1, &a2b2)
+	Fp2Add(&res.b, &t0, &t1)
+
+	// c2 = a0 b2 + a1 b1 + a2 b0
+	Fp2Mul(&t0, &x.a, &y.c)
+	Fp2Mul(&t1, &x.c, &y.a)
+	Fp2Add(&t0, &t0, &t1)
+	Fp2Add(&res.c, &t0, &a1b1)
+
+	*out = res
+}
+
+// fp6MulV -- out = x * v
+func fp6MulV(out, x *fp6) {
+	var t Fp2
+
+	fp2MulXi(&t, &x.c)
+	out.c = x.b
+	out.b = x.a
+	out.a = t
+}
+
+func fp6Inv(out, x *fp6) {
+	var t0, t1, t2, tmp, det Fp2
+
+	// t0 = a^2 - xi b c
+	Fp2Sqr(&t0, &x.a)
+	Fp2Mul(&tmp, &x.b, &x.c)
+	fp2MulXi(&tmp, &tmp)
+	Fp2Sub(&t0, &t0, &tmp)
+
+	// t1 = xi c^2 - a b
+	Fp2Sqr(&t1, &x.c)
+	fp2MulXi(&t1, &t1)
+	Fp2Mul(&tmp, &x.a, &x.b)
+	Fp2Sub(&t1, &t1, &tmp)
+
+	// t2 = b^2 - a c
+	Fp2Sqr(&t2, &x.b)
+	Fp2Mul(&tmp, &x.a, &x.c)
+	Fp2Sub(&t2, &t2, &tmp)
+
+	// det = a t0 + xi (c t1 + b t2)
+	Fp2Mul(&det, &x.c, &t1)
+	Fp2Mul(&tmp, &x.b, &t2)
+	Fp2Add(&det, &det, &tmp)
+	fp2MulXi(&det, &det)
+	Fp2Mul(&tmp, &x.a, &t0)
+	Fp2Add(&det, &det, &tmp)
+	Fp2Inv(&det, &det)
+
+	Fp2Mul(&out.a, &t0, &det)
+	Fp2Mul(&out.b, &t1, &det)
+	Fp2Mul(&out.c, &t2, &det)
+}
+
+func (x *fp12) setOne() {
+	*x = fp12{}
+	x.a.a.D[0].v = fpField.one
+}
+
+func (x *fp12) isOne() bool {
+	var one fp12
+
+	one.setOne()
+
+	return *x == one
+}
+
+// coefs returns the 12 Fp coefficients in mcl order
+func (x *fp12) coefs() []*Fp {
+	res := make([]*Fp, 0, 12)
+
+	for _, y := range []*fp6{&x.a, &x.b} {
+		for _, z := range []*Fp2{&y.a, &y.b, &y.c} {
+			res = append(res, &z.D[0], &z.D[1])
+		}
+	}
+
+	return res
+}
+
+func fp12Mul(out, x, y *fp12) {
+	var a0b0, a1b1, t0, t1 fp6
+
+	fp6Mul(&a0b0, &x.a, &y.a)
+	fp6Mul(&a1b1, &x.b, &y.b)
+
+	// b = (a0 + a1)(b0 + b1) - a0 b0 - a1 b1
+	fp6Add(&t0, &x.a, &x.b)
+	fp6Add(&t1, &y.a, &y.b)
+	fp6Mul(&t0, &t0, &t1)
+	fp6Sub(&t0, &t0, &a0b0)
+	fp6Sub(&out.b, &t0, &a1b1)
+
+	// a = a0 b0 + a1 b1 v
+	fp6MulV(&a1b1, &a1b1)
+	fp6Add(&out.a, &a0b0, &a1b1)
+}
+
+func fp12Inv(out, x *fp12) {
+	// 1 / (a + b w) = (a - b w) / (a^2 - b^2 v)
+	var t0, t1 fp6
+
+	fp6Mul(&t0, &x.a, &x.a)
+	fp6Mul(&t1, &x.b, &x.b)
+	fp6MulV(&t1, &t1)
+	fp6Sub(&t0, &t0, &t1)
+	fp6Inv(&t0, &t0)
+
+	fp6Mul(&out.a, &x.a, &t0)
+	fp6Mul(&out.b, &x.b, &t0)
+	fp6Neg(&out.b, &out.b)
+}
+
+// fp12Conj -- out = a - b w which is x^(p^6)
+func fp12Conj(out, x *fp12) {
+	out.a = x.a
+	fp6Neg(&out.b, &x.b)
+}
+
+// fp12Frobenius -- out = x^p
+func fp12Frobenius(out, x *fp12) {
+	// coefficient of w^k is multiplied by xi^(k(p-1)/6) after conjugation
+	res := *x
+
+	for i, y := range []*fp6{&res.a, &res.b} {
+		for j, z := range []*Fp2{&y.a, &y.b, &y.c} {
+			fp2Conj(z, z)
+			Fp2Mul(z, z, &bn.frobeniusCoef[i+2*j])
+		}
+	}
+
+	*out = res
+}
+
+func fp12Exp(out, x *fp12, e *big.Int) {
+	var res fp12
+
+	res.setOne()
+	base := *x
+
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		fp12Mul(&res, &res, &res)
+
+		if e.Bit(i) == 1 {
+			fp12Mul(&res, &res, &base)
+		}
+	}
+
+	*out = res
+}
+
+// GT --
+type GT struct {
+	v fp12
+}
+
+// Clear --
+func (x *GT) Clear() {
+	x.v = fp12{}
+}
+
+// SetInt64 --
+func (x *GT) SetInt64(v int64) {
+	x.v = fp12{}
+	x.v.a.a.D[0].SetInt64(v)
+}
+
+// SetString --
+func (x *GT) SetString(s string, base int) error {
+	parts := strings.Fields(s)
+	if len(parts) != 12 {
+		return fmt.Errorf("err GT.SetString expect 12 coefficients but got %d", len(parts))
+	}
+
+	var res fp12
+
+	for i, c := range res.coefs() {
+		if err := c.SetString(parts[i], base); err != nil {
+			return fmt.Errorf("err GT.SetString %w", err)
+		}
+	}
+
+	x.v = res
+
+	return nil
+}
+
+// Deserialize --
+func (x *GT) Deserialize(buf []byte) error {
+	if len(buf) != 12*32 {
+		return fmt.Errorf("err GT.Deserialize %x", buf)
+	}
+
+	var res fp12
+
+	for i, c := range res.coefs() {
+		if err := c.Deserialize(buf[i*32 : (i+1)*32]); err != nil {
+			return fmt.Errorf("err GT.Deserialize %x", buf)
+		}
+	}
+
+	x.v = res
+
+	return nil
+}
+
+// IsEqual --
+func (x *GT) IsEqual(rhs *GT) bool {
+	return x.v == rhs.v
+}
+
+// IsZero --
+func (x *GT) IsZero() bool {
+	return x.v == fp12{}
+}
+
+// IsOne --
+func (x *GT) IsOne() bool {
+	return x.v.isOne()
+}
+
+// GetString --
+func (x *GT) GetString(base int) string {
+	parts := make([]string, 0, 12)
+
+	for _, c := range x.v.coefs() {
+		parts = append(parts, c.GetString(base))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Serialize --
+func (x *GT) Serialize() []byte {
+	buf := make([]byte, 0, 12*32)
+
+	for _, c := range x.v.coefs() {
+		buf = append(buf, c.Serialize()...)
+	}
+
+	return buf
+}
+
+// GTNeg --
+func GTNeg(out *GT, x *GT) {
+	fp6Neg(&out.v.a, &x.v.a)
+	fp6Neg(&out.v.b, &x.v.b)
+}
+
+// GTInv -- conjugate of x which is equal to the inverse of x if x is in GT
+func GTInv(out *GT, x *GT) {
+	fp12Conj(&out.v, &x.v)
+}
+
+// GTInvGeneric -- inverse of any non zero x in Fp12
+func GTInvGeneric(out *GT, x *GT) {
+	fp12Inv(&out.v, &x.v)
+}
+
+// GTAdd --
+func GTAdd(out *GT, x *GT, y *GT) {
+	fp6Add(&out.v.a, &x.v.a, &y.v.a)
+	fp6Add(&out.v.b, &x.v.b, &y.v.b)
+}
+
+// GTSub --
+func GTSub(out *GT, x *GT, y *GT) {
+	fp6Sub(&out.v.a, &x.v.a, &y.v.a)
+	fp6Sub(&out.v.b, &x.v.b, &y.v.b)
+}
+
+// GTMul --
+func GTMul(out *GT, x *GT, y *GT) {
+	fp12Mul(&out.v, &x.v, &y.v)
+}
+
+// GTDiv --
+func GTDiv(out *GT, x *GT, y *GT) {
+	var inv fp12
+
+	fp12Inv(&inv, &y.v)
+	fp12Mul(&out.v, &x.v, &inv)
+}
+
+// GTPow --
+func GTPow(out *GT, x *GT, y *Fr) {
+	fp12Exp(&out.v, &x.v, frField.fromMont(&y.v))
+}
+
+// GTPowGeneric -- power of any x in Fp12
+func GTPowGeneric(out *GT, x *GT, y *Fr) {
+	GTPow(out, x, y)
+}
+
+// GetFp12 -- tower coefficients of x
+func (x *GT) GetFp12() Fp12 {
+	return Fp12{D: [2]Fp6{
+		{D: [3]Fp2{x.v.a.a, x.v.a.b, x.v.a.c}},
+		{D: [3]Fp2{x.v.b.a, x.v.b.b, x.v.b.c}},
+	}}
+}
+
+// SetFp12 --
+func (x *GT) SetFp12(y *Fp12) {
+	x.v = fp12{
+		a: fp6{a: y.D[0].D[0], b: y.D[0].D[1], c: y.D[0].D[2]},
+		b: fp6{a: y.D[1].D[0], b: y.D[1].D[1], c: y.D[1].D[2]},
+	}
+}
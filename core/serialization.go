@@ -1,14 +1,42 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 )
 
+const (
+	// compressedYSignFlag is set in the most significant byte of compressed point when y is "negative"
+	compressedYSignFlag = 1 << 7
+	// compressedInfinityFlag is set in the most significant byte of compressed point at infinity
+	compressedInfinityFlag = 1 << 6
+	compressedFlagsMask    = compressedYSignFlag | compressedInfinityFlag
+)
+
+var (
+	// ErrNonCanonical is returned when encoded field element is not less than the field modulus
+	ErrNonCanonical = errors.New("non-canonical field element")
+	// ErrNotOnCurve is returned when decoded point does not satisfy the curve equation
+	ErrNotOnCurve = errors.New("point is not on curve")
+	// ErrNotInSubgroup is returned when decoded point is not in the prime-order subgroup
+	ErrNotInSubgroup = errors.New("point is not in the prime-order subgroup")
+	// ErrInfinity is returned when decoded point is the identity element
+	ErrInfinity = errors.New("point is the identity element")
+)
+
+// G1ToBytes encodes point as 64 bytes little-endian (x, y). Point at infinity is encoded as all zeros.
+// The point is not modified
 func G1ToBytes(p *G1) []byte {
-	G1Normalize(p, p)
+	if p.IsZero() {
+		return make([]byte, 64)
+	}
+
+	var np G1
+
+	G1Normalize(&np, p)
 
-	a := padLeftOrTrim(p.X.Serialize(), 32)
-	b := padLeftOrTrim(p.Y.Serialize(), 32)
+	a := padLeftOrTrim(np.X.Serialize(), 32)
+	b := padLeftOrTrim(np.Y.Serialize(), 32)
 
 	res := make([]byte, len(a)+len(b))
 	copy(res, a)
@@ -17,13 +45,21 @@ func G1ToBytes(p *G1) []byte {
 	return res
 }
 
+// G2ToBytes encodes point as 128 bytes little-endian (x.real, x.imag, y.real, y.imag).
+// Point at infinity is encoded as all zeros. The point is not modified
 func G2ToBytes(p *G2) []byte {
-	G2Normalize(p, p)
+	if p.IsZero() {
+		return make([]byte, 128)
+	}
+
+	var np G2
+
+	G2Normalize(&np, p)
 
-	a := padLeftOrTrim(p.X.D[0].Serialize(), 32)
-	b := padLeftOrTrim(p.X.D[1].Serialize(), 32)
-	c := padLeftOrTrim(p.Y.D[0].Serialize(), 32)
-	d := padLeftOrTrim(p.Y.D[1].Serialize(), 32)
+	a := padLeftOrTrim(np.X.D[0].Serialize(), 32)
+	b := padLeftOrTrim(np.X.D[1].Serialize(), 32)
+	c := padLeftOrTrim(np.Y.D[0].Serialize(), 32)
+	d := padLeftOrTrim(np.Y.D[1].Serialize(), 32)
 
 	res := make([]byte, len(a)+len(b)+len(c)+len(d))
 	copy(res, a)
@@ -34,16 +70,22 @@ func G2ToBytes(p *G2) []byte {
 	return res
 }
 
+// G1FromBytes decodes point from 64 bytes little-endian (x, y) encoding.
+// Point is not checked to be on the curve, use ValidateG1 for that
 func G1FromBytes(raw []byte) (*G1, error) {
 	if len(raw) != 64 {
 		return nil, fmt.Errorf("expect length 64 but got %d", len(raw))
 	}
 
 	g1 := new(G1)
+	if isAllZero(raw) {
+		return g1, nil
+	}
+
 	offset := 0
 
 	for _, x := range []*Fp{&g1.X, &g1.Y} {
-		if err := x.SetLittleEndian(raw[offset : offset+32]); err != nil {
+		if err := fpFromLittleEndian(x, raw[offset:offset+32]); err != nil {
 			return nil, err
 		}
 
@@ -55,16 +97,22 @@ func G1FromBytes(raw []byte) (*G1, error) {
 	return g1, nil
 }
 
+// G2FromBytes decodes point from 128 bytes little-endian (x.real, x.imag, y.real, y.imag) encoding.
+// Point is not checked to be on the curve, use ValidateG2 for that
 func G2FromBytes(raw []byte) (*G2, error) {
 	if len(raw) != 128 {
 		return nil, fmt.Errorf("expect length 128 but got %d", len(raw))
 	}
 
 	g2 := new(G2)
+	if isAllZero(raw) {
+		return g2, nil
+	}
+
 	offset := 0
 
 	for _, x := range []*Fp{&g2.X.D[0], &g2.X.D[1], &g2.Y.D[0], &g2.Y.D[1]} {
-		if err := x.SetLittleEndian(raw[offset : offset+32]); err != nil {
+		if err := fpFromLittleEndian(x, raw[offset:offset+32]); err != nil {
 			return nil, err
 		}
 
@@ -76,6 +124,368 @@ func G2FromBytes(raw []byte) (*G2, error) {
 	return g2, nil
 }
 
+// G1ToEVM encodes point as 64 bytes big-endian (x, y) used by EIP-196/197 precompiles.
+// Point at infinity is encoded as all zeros
+func G1ToEVM(p *G1) []byte {
+	res := make([]byte, 64)
+	if p.IsZero() {
+		return res
+	}
+
+	var np G1
+
+	G1Normalize(&np, p)
+
+	copy(res, fpToBigEndian(&np.X))
+	copy(res[32:], fpToBigEndian(&np.Y))
+
+	return res
+}
+
+// G2ToEVM encodes point as 128 bytes big-endian (x.imag, x.real, y.imag, y.real)
+// used by EIP-197 precompile. Point at infinity is encoded as all zeros
+func G2ToEVM(p *G2) []byte {
+	res := make([]byte, 128)
+	if p.IsZero() {
+		return res
+	}
+
+	var np G2
+
+	G2Normalize(&np, p)
+
+	copy(res, fpToBigEndian(&np.X.D[1]))
+	copy(res[32:], fpToBigEndian(&np.X.D[0]))
+	copy(res[64:], fpToBigEndian(&np.Y.D[1]))
+	copy(res[96:], fpToBigEndian(&np.Y.D[0]))
+
+	return res
+}
+
+// G1FromEVM decodes point from 64 bytes big-endian (x, y) encoding
+func G1FromEVM(raw []byte) (*G1, error) {
+	if len(raw) != 64 {
+		return nil, fmt.Errorf("expect length 64 but got %d", len(raw))
+	}
+
+	g1 := new(G1)
+	if isAllZero(raw) {
+		return g1, nil
+	}
+
+	offset := 0
+
+	for _, x := range []*Fp{&g1.X, &g1.Y} {
+		if err := fpFromBigEndian(x, raw[offset:offset+32]); err != nil {
+			return nil, err
+		}
+
+		offset += 32
+	}
+
+	g1.Z.SetInt64(1)
+
+	return g1, nil
+}
+
+// G2FromEVM decodes point from 128 bytes big-endian (x.imag, x.real, y.imag, y.real) encoding
+func G2FromEVM(raw []byte) (*G2, error) {
+	if len(raw) != 128 {
+		return nil, fmt.Errorf("expect length 128 but got %d", len(raw))
+	}
+
+	g2 := new(G2)
+	if isAllZero(raw) {
+		return g2, nil
+	}
+
+	offset := 0
+
+	for _, x := range []*Fp{&g2.X.D[1], &g2.X.D[0], &g2.Y.D[1], &g2.Y.D[0]} {
+		if err := fpFromBigEndian(x, raw[offset:offset+32]); err != nil {
+			return nil, err
+		}
+
+		offset += 32
+	}
+
+	g2.Z.D[0].SetInt64(1)
+
+	return g2, nil
+}
+
+// G1ToCompressedBytes encodes point as 32 bytes little-endian x with
+// y sign and infinity flags in the most significant bits of the last byte
+func G1ToCompressedBytes(p *G1) []byte {
+	res := make([]byte, 32)
+	if p.IsZero() {
+		res[31] = compressedInfinityFlag
+
+		return res
+	}
+
+	var np G1
+
+	G1Normalize(&np, p)
+
+	copy(res, np.X.Serialize())
+
+	if np.Y.IsOdd() {
+		res[31] |= compressedYSignFlag
+	}
+
+	return res
+}
+
+// G2ToCompressedBytes encodes point as 64 bytes little-endian (x.real, x.imag) with
+// y sign and infinity flags in the most significant bits of the last byte
+func G2ToCompressedBytes(p *G2) []byte {
+	res := make([]byte, 64)
+	if p.IsZero() {
+		res[63] = compressedInfinityFlag
+
+		return res
+	}
+
+	var np G2
+
+	G2Normalize(&np, p)
+
+	copy(res, np.X.D[0].Serialize())
+	copy(res[32:], np.X.D[1].Serialize())
+
+	if fp2IsOdd(&np.Y) {
+		res[63] |= compressedYSignFlag
+	}
+
+	return res
+}
+
+// G1FromCompressedBytes decodes point from 32 bytes compressed encoding
+func G1FromCompressedBytes(raw []byte) (*G1, error) {
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("expect length 32 but got %d", len(raw))
+	}
+
+	g1 := new(G1)
+
+	buf, flags := splitCompressedFlags(raw)
+	if flags&compressedInfinityFlag != 0 {
+		if flags != compressedInfinityFlag || !isAllZero(buf) {
+			return nil, errors.New("invalid compressed point at infinity")
+		}
+
+		return g1, nil
+	}
+
+	if err := fpFromLittleEndian(&g1.X, buf); err != nil {
+		return nil, err
+	}
+
+	if !g1RecoverY(g1) {
+		return nil, ErrNotOnCurve
+	}
+
+	if g1.Y.IsOdd() != (flags&compressedYSignFlag != 0) {
+		FpNeg(&g1.Y, &g1.Y)
+	}
+
+	return g1, nil
+}
+
+// G2FromCompressedBytes decodes point from 64 bytes compressed encoding
+func G2FromCompressedBytes(raw []byte) (*G2, error) {
+	if len(raw) != 64 {
+		return nil, fmt.Errorf("expect length 64 but got %d", len(raw))
+	}
+
+	g2 := new(G2)
+
+	buf, flags := splitCompressedFlags(raw)
+	if flags&compressedInfinityFlag != 0 {
+		if flags != compressedInfinityFlag || !isAllZero(buf) {
+			return nil, errors.New("invalid compressed point at infinity")
+		}
+
+		return g2, nil
+	}
+
+	if err := fpFromLittleEndian(&g2.X.D[0], buf[:32]); err != nil {
+		return nil, err
+	}
+
+	if err := fpFromLittleEndian(&g2.X.D[1], buf[32:]); err != nil {
+		return nil, err
+	}
+
+	if !g2RecoverY(g2) {
+		return nil, ErrNotOnCurve
+	}
+
+	if fp2IsOdd(&g2.Y) != (flags&compressedYSignFlag != 0) {
+		Fp2Neg(&g2.Y, &g2.Y)
+	}
+
+	return g2, nil
+}
+
+// g1Weierstrass calculates x^3 + b
+func g1Weierstrass(out *Fp, x *Fp) {
+	FpSqr(out, x)
+	FpMul(out, out, x)
+	FpAdd(out, out, &g1B)
+}
+
+// g2Weierstrass calculates x^3 + b
+func g2Weierstrass(out *Fp2, x *Fp2) {
+	Fp2Sqr(out, x)
+	Fp2Mul(out, out, x)
+	Fp2Add(out, out, &g2B)
+}
+
+// g1RecoverY sets y to one of the square roots of x^3 + b and z to one.
+// Returns false if x is not x coordinate of any point on the curve
+func g1RecoverY(p *G1) bool {
+	g1Weierstrass(&p.Y, &p.X)
+
+	if !FpSquareRoot(&p.Y, &p.Y) {
+		return false
+	}
+
+	p.Z.SetInt64(1)
+
+	return true
+}
+
+// g2RecoverY sets y to one of the square roots of x^3 + b and z to one.
+// Returns false if x is not x coordinate of any point on the twist curve
+func g2RecoverY(p *G2) bool {
+	g2Weierstrass(&p.Y, &p.X)
+
+	if !Fp2SquareRoot(&p.Y, &p.Y) {
+		return false
+	}
+
+	p.Z.D[0].SetInt64(1)
+	p.Z.D[1].Clear()
+
+	return true
+}
+
+// splitCompressedFlags returns copy of the compressed encoding without flags and the flags
+func splitCompressedFlags(raw []byte) ([]byte, byte) {
+	buf := make([]byte, len(raw))
+	copy(buf, raw)
+
+	flags := buf[len(buf)-1] & compressedFlagsMask
+	buf[len(buf)-1] &^= compressedFlagsMask
+
+	return buf, flags
+}
+
+// fp2IsOdd returns parity of the real part or parity of the imaginary part if the real part is zero
+func fp2IsOdd(x *Fp2) bool {
+	if x.D[0].IsZero() {
+		return x.D[1].IsOdd()
+	}
+
+	return x.D[0].IsOdd()
+}
+
+// ValidateG1 checks that point is not the identity element, that it is on the curve
+// and that it belongs to the prime-order subgroup
+func ValidateG1(p *G1) error {
+	if p.IsZero() {
+		return ErrInfinity
+	}
+
+	var np G1
+
+	G1Normalize(&np, p)
+
+	left, right := new(Fp), new(Fp)
+
+	FpSqr(left, &np.Y)
+	g1Weierstrass(right, &np.X)
+
+	if !left.IsEqual(right) {
+		return ErrNotOnCurve
+	}
+
+	if !np.IsValidOrder() {
+		return ErrNotInSubgroup
+	}
+
+	return nil
+}
+
+// ValidateG2 checks that point is not the identity element, that it is on the twist curve
+// and that it belongs to the prime-order subgroup
+func ValidateG2(p *G2) error {
+	if p.IsZero() {
+		return ErrInfinity
+	}
+
+	var np G2
+
+	G2Normalize(&np, p)
+
+	left, right := new(Fp2), new(Fp2)
+
+	Fp2Sqr(left, &np.Y)
+	g2Weierstrass(right, &np.X)
+
+	if !left.IsEqual(right) {
+		return ErrNotOnCurve
+	}
+
+	if !np.IsValidOrder() {
+		return ErrNotInSubgroup
+	}
+
+	return nil
+}
+
+// fpFromLittleEndian sets field element from 32 bytes little-endian representation.
+// Values which are not less than the field modulus are rejected
+func fpFromLittleEndian(x *Fp, raw []byte) error {
+	if err := x.Deserialize(raw); err != nil {
+		return fmt.Errorf("%w: %x", ErrNonCanonical, raw)
+	}
+
+	return nil
+}
+
+// fpToBigEndian returns 32 bytes big-endian representation of the field element
+func fpToBigEndian(x *Fp) []byte {
+	return reverseBytes(x.Serialize())
+}
+
+// fpFromBigEndian sets field element from 32 bytes big-endian representation.
+// Values which are not less than the field modulus are rejected
+func fpFromBigEndian(x *Fp, raw []byte) error {
+	return fpFromLittleEndian(x, reverseBytes(raw))
+}
+
+func reverseBytes(bb []byte) []byte {
+	res := make([]byte, len(bb))
+	for i, b := range bb {
+		res[len(bb)-1-i] = b
+	}
+
+	return res
+}
+
+func isAllZero(bb []byte) bool {
+	for _, b := range bb {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
 func padLeftOrTrim(bb []byte, size int) []byte {
 	l := len(bb)
 	if l == size {
@@ -0,0 +1,341 @@
+package core
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EVMGenerators(t *testing.T) {
+	t.Parallel()
+
+	g1 := new(G1)
+	require.NoError(t, g1.SetString("1 1 2", 10))
+
+	g1EVM := G1ToEVM(g1)
+	assert.Equal(t,
+		"0000000000000000000000000000000000000000000000000000000000000001"+
+			"0000000000000000000000000000000000000000000000000000000000000002",
+		hex.EncodeToString(g1EVM))
+
+	g2EVM := G2ToEVM(ellipticCurveG2)
+	assert.Equal(t,
+		"198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2"+
+			"1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed"+
+			"090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b"+
+			"12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		hex.EncodeToString(g2EVM))
+
+	g1Res, err := G1FromEVM(g1EVM)
+	require.NoError(t, err)
+	assert.True(t, g1.IsEqual(g1Res))
+
+	g2Res, err := G2FromEVM(g2EVM)
+	require.NoError(t, err)
+	assert.True(t, ellipticCurveG2.IsEqual(g2Res))
+}
+
+func Test_EVMInfinityAndInvalid(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, make([]byte, 64), G1ToEVM(new(G1)))
+	assert.Equal(t, make([]byte, 128), G2ToEVM(new(G2)))
+
+	g1, err := G1FromEVM(make([]byte, 64))
+	require.NoError(t, err)
+	assert.True(t, g1.IsZero())
+
+	g2, err := G2FromEVM(make([]byte, 128))
+	require.NoError(t, err)
+	assert.True(t, g2.IsZero())
+
+	_, err = G1FromEVM(make([]byte, 63))
+	assert.Error(t, err)
+
+	_, err = G2FromEVM(nil)
+	assert.Error(t, err)
+
+	// field modulus itself is not a canonical coordinate
+	modulus, _ := hex.DecodeString("30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47")
+	raw := make([]byte, 64)
+	copy(raw, modulus)
+
+	_, err = G1FromEVM(raw)
+	assert.Error(t, err)
+}
+
+func Test_EVMMarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	msg := testGenRandomBytes(t, messageSize)
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	sig, err := key.Sign(msg)
+	require.NoError(t, err)
+
+	sigEVM, err := sig.MarshalEVM()
+	require.NoError(t, err)
+
+	pubEVM := key.PublicKey().MarshalEVM()
+
+	sig2, err := UnmarshalSignatureEVM(sigEVM)
+	require.NoError(t, err)
+
+	pub2, err := UnmarshalPublicKeyEVM(pubEVM)
+	require.NoError(t, err)
+
+	assert.True(t, sig2.Verify(pub2, msg))
+	assert.Equal(t, pubEVM, pub2.MarshalEVM())
+
+	sigBytes, err := sig.Marshal()
+	require.NoError(t, err)
+
+	sig2Bytes, err := sig2.Marshal()
+	require.NoError(t, err)
+
+	assert.Equal(t, sigBytes, sig2Bytes)
+
+	_, err = (&Signature{}).MarshalEVM()
+	assert.Error(t, err)
+	assert.Nil(t, (&PublicKey{}).MarshalEVM())
+}
+
+func Test_CompressedMarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	msg := testGenRandomBytes(t, messageSize)
+
+	keys, err := CreateRandomBlsKeys(16)
+	require.NoError(t, err)
+
+	for _, key := range keys {
+		pub := key.PublicKey()
+
+		sig, err := key.Sign(msg)
+		require.NoError(t, err)
+
+		sigCompressed, err := sig.MarshalCompressed()
+		require.NoError(t, err)
+		require.Len(t, sigCompressed, 32)
+
+		pubCompressed := pub.MarshalCompressed()
+		require.Len(t, pubCompressed, 64)
+
+		sig2, err := UnmarshalSignature(sigCompressed)
+		require.NoError(t, err)
+
+		pub2, err := UnmarshalPublicKey(pubCompressed)
+		require.NoError(t, err)
+
+		assert.True(t, sig2.Verify(pub2, msg))
+		assert.Equal(t, pub.Marshal(), pub2.Marshal())
+
+		sigBytes, err := sig.Marshal()
+		require.NoError(t, err)
+
+		sig2Bytes, err := sig2.Marshal()
+		require.NoError(t, err)
+
+		assert.Equal(t, sigBytes, sig2Bytes)
+	}
+}
+
+func Test_CompressedInfinityAndInvalid(t *testing.T) {
+	t.Parallel()
+
+	g1Raw := G1ToCompressedBytes(new(G1))
+	assert.Equal(t, byte(compressedInfinityFlag), g1Raw[31])
+
+	g1, err := G1FromCompressedBytes(g1Raw)
+	require.NoError(t, err)
+	assert.True(t, g1.IsZero())
+
+	g2Raw := G2ToCompressedBytes(new(G2))
+	assert.Equal(t, byte(compressedInfinityFlag), g2Raw[63])
+
+	g2, err := G2FromCompressedBytes(g2Raw)
+	require.NoError(t, err)
+	assert.True(t, g2.IsZero())
+
+	// infinity flag together with non zero x
+	g1Raw[0] = 1
+
+	_, err = G1FromCompressedBytes(g1Raw)
+	assert.Error(t, err)
+
+	// x = 0 is not on the curve since 3 is not a quadratic residue
+	_, err = G1FromCompressedBytes(make([]byte, 32))
+	assert.ErrorIs(t, err, ErrNotOnCurve)
+
+	_, err = G2FromCompressedBytes(make([]byte, 63))
+	assert.Error(t, err)
+}
+
+func Test_CompressedGenerator(t *testing.T) {
+	t.Parallel()
+
+	raw := G2ToCompressedBytes(ellipticCurveG2)
+
+	g2, err := G2FromCompressedBytes(raw)
+	require.NoError(t, err)
+	assert.True(t, ellipticCurveG2.IsEqual(g2))
+
+	raw[63] ^= compressedYSignFlag
+
+	g2, err = G2FromCompressedBytes(raw)
+	require.NoError(t, err)
+
+	G2Neg(g2, g2)
+	assert.True(t, ellipticCurveG2.IsEqual(g2))
+}
+
+func Test_StrictDecodingErrors(t *testing.T) {
+	t.Parallel()
+
+	modulus, _ := hex.DecodeString("30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47")
+
+	// non-canonical x coordinate
+	raw := make([]byte, 64)
+	copy(raw, reverseBytes(modulus))
+
+	_, err := UnmarshalSignature(raw)
+	assert.ErrorIs(t, err, ErrNonCanonical)
+
+	// (1, 1) is not on the curve
+	raw = make([]byte, 64)
+	raw[0], raw[32] = 1, 1
+
+	_, err = UnmarshalSignature(raw)
+	assert.ErrorIs(t, err, ErrNotOnCurve)
+
+	_, err = UnmarshalSignature(make([]byte, 64))
+	assert.ErrorIs(t, err, ErrInfinity)
+
+	_, err = UnmarshalSignature(G1ToCompressedBytes(new(G1)))
+	assert.ErrorIs(t, err, ErrInfinity)
+
+	_, err = UnmarshalSignatureEVM(make([]byte, 64))
+	assert.ErrorIs(t, err, ErrInfinity)
+
+	_, err = UnmarshalPublicKey(make([]byte, 128))
+	assert.ErrorIs(t, err, ErrInfinity)
+
+	_, err = UnmarshalPublicKey(G2ToCompressedBytes(new(G2)))
+	assert.ErrorIs(t, err, ErrInfinity)
+
+	_, err = UnmarshalPublicKeyEVM(make([]byte, 128))
+	assert.ErrorIs(t, err, ErrInfinity)
+
+	raw = G2ToBytes(ellipticCurveG2)
+	raw[0] ^= 1
+
+	_, err = UnmarshalPublicKey(raw)
+	assert.ErrorIs(t, err, ErrNotOnCurve)
+
+	// point on the twist curve which is not in the prime-order subgroup
+	raw = make([]byte, 64)
+
+	var g2 *G2
+
+	for i := 1; g2 == nil; i++ {
+		raw[0] = byte(i)
+		g2, _ = G2FromCompressedBytes(raw)
+	}
+
+	assert.ErrorIs(t, ValidateG2(g2), ErrNotInSubgroup)
+
+	_, err = UnmarshalPublicKey(raw)
+	assert.ErrorIs(t, err, ErrNotInSubgroup)
+
+	_, err = UnmarshalPublicKey(G2ToBytes(g2))
+	assert.ErrorIs(t, err, ErrNotInSubgroup)
+
+	_, err = UnmarshalPublicKeyEVM(G2ToEVM(g2))
+	assert.ErrorIs(t, err, ErrNotInSubgroup)
+}
+
+func FuzzG1FromBytes(f *testing.F) {
+	f.Add(G1ToBytes(testHashToG1(f)))
+	f.Add(make([]byte, 64))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		g1, err := G1FromBytes(raw)
+		if err == nil {
+			require.Equal(t, raw, G1ToBytes(g1))
+		}
+	})
+}
+
+func FuzzG2FromBytes(f *testing.F) {
+	f.Add(G2ToBytes(ellipticCurveG2))
+	f.Add(make([]byte, 128))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		g2, err := G2FromBytes(raw)
+		if err == nil {
+			require.Equal(t, raw, G2ToBytes(g2))
+		}
+	})
+}
+
+func FuzzG1FromEVM(f *testing.F) {
+	f.Add(G1ToEVM(testHashToG1(f)))
+	f.Add(make([]byte, 64))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		g1, err := G1FromEVM(raw)
+		if err == nil {
+			require.Equal(t, raw, G1ToEVM(g1))
+		}
+	})
+}
+
+func FuzzG2FromEVM(f *testing.F) {
+	f.Add(G2ToEVM(ellipticCurveG2))
+	f.Add(make([]byte, 128))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		g2, err := G2FromEVM(raw)
+		if err == nil {
+			require.Equal(t, raw, G2ToEVM(g2))
+		}
+	})
+}
+
+func FuzzG1FromCompressedBytes(f *testing.F) {
+	f.Add(G1ToCompressedBytes(testHashToG1(f)))
+	f.Add(G1ToCompressedBytes(new(G1)))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		g1, err := G1FromCompressedBytes(raw)
+		if err == nil {
+			require.Equal(t, raw, G1ToCompressedBytes(g1))
+		}
+	})
+}
+
+func FuzzG2FromCompressedBytes(f *testing.F) {
+	f.Add(G2ToCompressedBytes(ellipticCurveG2))
+	f.Add(G2ToCompressedBytes(new(G2)))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		g2, err := G2FromCompressedBytes(raw)
+		if err == nil {
+			require.Equal(t, raw, G2ToCompressedBytes(g2))
+		}
+	})
+}
+
+// testHashToG1 returns some valid G1 point
+func testHashToG1(tb testing.TB) *G1 {
+	tb.Helper()
+
+	g1, err := HashToG1([]byte("test"))
+	require.NoError(tb, err)
+
+	return g1
+}
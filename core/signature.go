@@ -5,84 +5,185 @@ import (
 	"fmt"
 )
 
-// Signature represents bls signature which is point on the curve
+var errEmptySignatureMarshalling = errors.New("cannot marshal empty signature")
+
+// Signature represents bls signature which is point on the curve. The point is normalized at
+// construction and never modified afterwards, so Signature is safe for concurrent use
 type Signature struct {
 	p *G1
 }
 
-// Verify checks the BLS signature of the message against the public key of its signer
-func (s *Signature) Verify(publicKey *PublicKey, message []byte) bool {
-	messagePoint, err := HashToG1(message)
-	if err != nil {
-		return false
-	}
+// newSignature normalizes the point and wraps it. The point must not be shared
+func newSignature(p *G1) *Signature {
+	G1Normalize(p, p)
 
-	e1, e2 := new(GT), new(GT)
+	return &Signature{p: p}
+}
 
-	G1Neg(messagePoint, messagePoint)
-	PrecomputedMillerLoop(e1, s.p, GetCoef())
-	MillerLoop(e2, messagePoint, publicKey.p)
-	GTMul(e1, e1, e2)
-	FinalExp(e1, e1)
+// Verify checks the BLS signature of the message against the public key of its signer
+func (s *Signature) Verify(publicKey *PublicKey, message []byte) bool {
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
 
-	return e1.IsOne()
+	return v.Verify(s, publicKey, message)
 }
 
 // VerifyAggregated checks the BLS signature of the message against the aggregated public keys of its signers
 func (s *Signature) VerifyAggregated(publicKeys []*PublicKey, msg []byte) bool {
-	return s.Verify(AggregatePublicKeys(publicKeys), msg)
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+
+	return v.VerifyAggregated(s, publicKeys, msg)
 }
 
 // Aggregate adds the given signatures
 func (s *Signature) Aggregate(next *Signature) *Signature {
-	newp := new(G1)
+	return s.AggregateInto(new(Signature), next)
+}
 
-	if s.p != nil {
-		if next.p != nil {
-			G1Add(newp, s.p, next.p)
-		} else {
-			G1Add(newp, newp, s.p)
-		}
-	} else if next.p != nil {
-		G1Add(newp, newp, next.p)
+// AggregateInto sets dst to the sum of s and next and returns dst. The point of dst is reused,
+// so accumulating with s.AggregateInto(s, next) does not allocate. As dst is modified,
+// it must not be shared with other goroutines until aggregation is done
+func (s *Signature) AggregateInto(dst *Signature, next *Signature) *Signature {
+	if dst.p == nil {
+		dst.p = new(G1)
 	}
 
-	return &Signature{p: newp}
+	switch {
+	case s.p != nil && next.p != nil:
+		G1Add(dst.p, s.p, next.p)
+	case s.p != nil:
+		*dst.p = *s.p
+	case next.p != nil:
+		*dst.p = *next.p
+	default:
+		dst.p.Clear()
+	}
+
+	G1Normalize(dst.p, dst.p)
+
+	return dst
+}
+
+// Remove subtracts the given signature from the aggregated one, e.g. to exclude a signer of s
+func (s *Signature) Remove(sig *Signature) *Signature {
+	res := new(G1)
+
+	switch {
+	case s.p != nil && sig.p != nil:
+		G1Sub(res, s.p, sig.p)
+	case s.p != nil:
+		*res = *s.p
+	case sig.p != nil:
+		G1Neg(res, sig.p)
+	}
+
+	return newSignature(res)
 }
 
 // Marshal the signature to bytes.
 func (s *Signature) Marshal() ([]byte, error) {
 	if s.p == nil {
-		return nil, errors.New("cannot marshal empty signature")
+		return nil, errEmptySignatureMarshalling
 	}
 
 	return G1ToBytes(s.p), nil
 }
 
+// MarshalCompressed marshals the signature to 32 bytes compressed form.
+func (s *Signature) MarshalCompressed() ([]byte, error) {
+	if s.p == nil {
+		return nil, errEmptySignatureMarshalling
+	}
+
+	return G1ToCompressedBytes(s.p), nil
+}
+
+// MarshalEVM marshals the signature to bytes in EIP-196/197 precompile layout.
+func (s *Signature) MarshalEVM() ([]byte, error) {
+	if s.p == nil {
+		return nil, errEmptySignatureMarshalling
+	}
+
+	return G1ToEVM(s.p), nil
+}
+
 func (s Signature) String() string {
 	return fmt.Sprintf("(%s, %s, %s)",
 		s.p.X.GetString(16), s.p.Y.GetString(16), s.p.Z.GetString(16))
 }
 
-// UnmarshalSignature reads the signature from the given byte array
+// UnmarshalSignature reads the signature from the given byte array.
+// Both uncompressed and compressed forms are accepted. Invalid points and the identity element are rejected
 func UnmarshalSignature(raw []byte) (*Signature, error) {
-	g1, err := G1FromBytes(raw)
+	g1, err := g1FromBytesStrict(raw)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Signature{p: g1}, nil
+	return newSignature(g1), nil
+}
+
+// UnmarshalSignatureEVM reads the signature from the given byte array in EIP-196/197 precompile layout
+func UnmarshalSignatureEVM(raw []byte) (*Signature, error) {
+	g1, err := G1FromEVM(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateG1(g1); err != nil {
+		return nil, err
+	}
+
+	return newSignature(g1), nil
 }
 
 // Aggregate sums the given array of signatures
 func AggregateSignatures(signatures []*Signature) *Signature {
-	newp := new(G1)
+	return AggregateSignaturesInto(new(Signature), signatures)
+}
+
+// AggregateSignaturesInto sets dst to the sum of the given signatures and returns dst.
+// The point of dst is reused, so dst must not be one of the signatures nor shared with other goroutines
+func AggregateSignaturesInto(dst *Signature, signatures []*Signature) *Signature {
+	if dst.p == nil {
+		dst.p = new(G1)
+	}
+
+	dst.p.Clear()
 
 	for _, x := range signatures {
 		if x.p != nil {
-			G1Add(newp, newp, x.p)
+			G1Add(dst.p, dst.p, x.p)
 		}
 	}
 
-	return &Signature{p: newp}
+	G1Normalize(dst.p, dst.p)
+
+	return dst
+}
+
+// g1FromBytesStrict decodes compressed or uncompressed point depending on the length of the input
+// and checks that the point is valid, see ValidateG1
+func g1FromBytesStrict(raw []byte) (*G1, error) {
+	var (
+		g1  *G1
+		err error
+	)
+
+	if len(raw) == 32 {
+		g1, err = G1FromCompressedBytes(raw)
+	} else {
+		g1, err = G1FromBytes(raw)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateG1(g1); err != nil {
+		return nil, err
+	}
+
+	return g1, nil
 }
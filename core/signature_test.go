@@ -164,7 +164,7 @@ func TestSignature_Unmarshal(t *testing.T) {
 }
 
 // testGenRandomBytes generates byte array with random data
-func testGenRandomBytes(t *testing.T, size int) (blk []byte) {
+func testGenRandomBytes(t testing.TB, size int) (blk []byte) {
 	t.Helper()
 
 	blk = make([]byte, size)
@@ -174,3 +174,62 @@ func testGenRandomBytes(t *testing.T, size int) (blk []byte) {
 
 	return
 }
+
+func FuzzUnmarshalSignature(f *testing.F) {
+	key, err := GenerateBlsKey()
+	require.NoError(f, err)
+
+	sig, err := key.Sign([]byte("fuzz"))
+	require.NoError(f, err)
+
+	raw, err := sig.Marshal()
+	require.NoError(f, err)
+
+	rawCompressed, err := sig.MarshalCompressed()
+	require.NoError(f, err)
+
+	f.Add(raw)
+	f.Add(rawCompressed)
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		sig, err := UnmarshalSignature(raw)
+		if err != nil {
+			return
+		}
+
+		var res []byte
+
+		if len(raw) == 32 {
+			res, err = sig.MarshalCompressed()
+		} else {
+			res, err = sig.Marshal()
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, raw, res)
+	})
+}
+
+func FuzzUnmarshalSignatureEVM(f *testing.F) {
+	key, err := GenerateBlsKey()
+	require.NoError(f, err)
+
+	sig, err := key.Sign([]byte("fuzz"))
+	require.NoError(f, err)
+
+	raw, err := sig.MarshalEVM()
+	require.NoError(f, err)
+
+	f.Add(raw)
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		sig, err := UnmarshalSignatureEVM(raw)
+		if err != nil {
+			return
+		}
+
+		res, err := sig.MarshalEVM()
+		require.NoError(t, err)
+		require.Equal(t, raw, res)
+	})
+}
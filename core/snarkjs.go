@@ -0,0 +1,218 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// snarkjs/circom represent field elements as decimal strings and points as arrays of
+// projective coordinates, e.g. ["x", "y", "1"] for G1 and [["x0", "x1"], ["y0", "y1"], ["1", "0"]] for G2.
+// Points at infinity are ["0", "1", "0"] and [["0", "0"], ["1", "0"], ["0", "0"]]
+
+var errInvalidSnarkjsPoint = errors.New("invalid snarkjs point")
+
+// G1ToSnarkjs encodes point as snarkjs ["x", "y", "1"] array
+func G1ToSnarkjs(p *G1) []string {
+	if p.IsZero() {
+		return []string{"0", "1", "0"}
+	}
+
+	var np G1
+
+	G1Normalize(&np, p)
+
+	return []string{np.X.GetString(10), np.Y.GetString(10), "1"}
+}
+
+// G1FromSnarkjs decodes point from snarkjs ["x", "y", "z"] array where z is either 0 or 1
+func G1FromSnarkjs(v []string) (*G1, error) {
+	if len(v) != 3 {
+		return nil, fmt.Errorf("%w: expect 3 coordinates but got %d", errInvalidSnarkjsPoint, len(v))
+	}
+
+	g1 := new(G1)
+
+	for i, x := range []*Fp{&g1.X, &g1.Y, &g1.Z} {
+		if err := fpFromDecimal(x, v[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if g1.Z.IsZero() {
+		return new(G1), nil
+	}
+
+	if !g1.Z.IsOne() {
+		return nil, fmt.Errorf("%w: z coordinate must be 0 or 1", errInvalidSnarkjsPoint)
+	}
+
+	if err := ValidateG1(g1); err != nil {
+		return nil, err
+	}
+
+	return g1, nil
+}
+
+// G2ToSnarkjs encodes point as snarkjs [["x0", "x1"], ["y0", "y1"], ["1", "0"]] array
+func G2ToSnarkjs(p *G2) [][]string {
+	if p.IsZero() {
+		return [][]string{{"0", "0"}, {"1", "0"}, {"0", "0"}}
+	}
+
+	var np G2
+
+	G2Normalize(&np, p)
+
+	return [][]string{
+		{np.X.D[0].GetString(10), np.X.D[1].GetString(10)},
+		{np.Y.D[0].GetString(10), np.Y.D[1].GetString(10)},
+		{"1", "0"},
+	}
+}
+
+// G2FromSnarkjs decodes point from snarkjs [["x0", "x1"], ["y0", "y1"], ["z0", "z1"]] array
+// where z is either 0 or 1
+func G2FromSnarkjs(v [][]string) (*G2, error) {
+	if len(v) != 3 {
+		return nil, fmt.Errorf("%w: expect 3 coordinates but got %d", errInvalidSnarkjsPoint, len(v))
+	}
+
+	g2 := new(G2)
+
+	for i, x := range []*Fp2{&g2.X, &g2.Y, &g2.Z} {
+		if err := fp2FromDecimal(x, v[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if g2.Z.IsZero() {
+		return new(G2), nil
+	}
+
+	if !g2.Z.IsOne() {
+		return nil, fmt.Errorf("%w: z coordinate must be 0 or 1", errInvalidSnarkjsPoint)
+	}
+
+	if err := ValidateG2(g2); err != nil {
+		return nil, err
+	}
+
+	return g2, nil
+}
+
+// FrToSnarkjs encodes scalar as decimal string
+func FrToSnarkjs(x *Fr) string {
+	return x.GetString(10)
+}
+
+// FrFromSnarkjs decodes scalar from decimal string
+func FrFromSnarkjs(s string) (*Fr, error) {
+	if err := checkDecimal(s); err != nil {
+		return nil, err
+	}
+
+	x := new(Fr)
+	if err := x.SetString(s, 10); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNonCanonical, s)
+	}
+
+	return x, nil
+}
+
+// GTToSnarkjs encodes GT element as snarkjs Fp12 array [[[c0, c1] x 3] x 2]
+func GTToSnarkjs(x *GT) [][][]string {
+	coefs := strings.Fields(x.GetString(10))
+	res := make([][][]string, 2)
+
+	for i := range res {
+		res[i] = make([][]string, 3)
+
+		for j := range res[i] {
+			offset := (i*3 + j) * 2
+			res[i][j] = []string{coefs[offset], coefs[offset+1]}
+		}
+	}
+
+	return res
+}
+
+// GTFromSnarkjs decodes GT element from snarkjs Fp12 array [[[c0, c1] x 3] x 2]
+func GTFromSnarkjs(v [][][]string) (*GT, error) {
+	if len(v) != 2 {
+		return nil, fmt.Errorf("expect 2 Fp6 coefficients but got %d", len(v))
+	}
+
+	coefs := make([]string, 0, 12)
+
+	for _, fp6 := range v {
+		if len(fp6) != 3 {
+			return nil, fmt.Errorf("expect 3 Fp2 coefficients but got %d", len(fp6))
+		}
+
+		for _, fp2 := range fp6 {
+			if len(fp2) != 2 {
+				return nil, fmt.Errorf("expect 2 Fp coefficients but got %d", len(fp2))
+			}
+
+			for _, s := range fp2 {
+				if err := checkDecimal(s); err != nil {
+					return nil, err
+				}
+
+				coefs = append(coefs, s)
+			}
+		}
+	}
+
+	x := new(GT)
+	if err := x.SetString(strings.Join(coefs, " "), 10); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNonCanonical, coefs)
+	}
+
+	return x, nil
+}
+
+// fpFromDecimal sets field element from decimal string. Values which are not less than
+// the field modulus are rejected
+func fpFromDecimal(x *Fp, s string) error {
+	if err := checkDecimal(s); err != nil {
+		return err
+	}
+
+	if err := x.SetString(s, 10); err != nil {
+		return fmt.Errorf("%w: %s", ErrNonCanonical, s)
+	}
+
+	return nil
+}
+
+// fp2FromDecimal sets Fp2 element from [real, imaginary] decimal strings
+func fp2FromDecimal(x *Fp2, v []string) error {
+	if len(v) != 2 {
+		return fmt.Errorf("expect 2 Fp coefficients but got %d", len(v))
+	}
+
+	for i := range x.D {
+		if err := fpFromDecimal(&x.D[i], v[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkDecimal checks that string is non negative decimal number without sign and spaces
+func checkDecimal(s string) error {
+	if s == "" {
+		return errors.New("empty decimal string")
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return fmt.Errorf("invalid decimal string %q", s)
+		}
+	}
+
+	return nil
+}
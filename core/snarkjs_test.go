@@ -0,0 +1,122 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SnarkjsGenerators(t *testing.T) {
+	t.Parallel()
+
+	g1 := new(G1)
+	require.NoError(t, g1.SetString("1 1 2", 10))
+
+	raw, err := json.Marshal(G1ToSnarkjs(g1))
+	require.NoError(t, err)
+	assert.Equal(t, `["1","2","1"]`, string(raw))
+
+	raw, err = json.Marshal(G2ToSnarkjs(ellipticCurveG2))
+	require.NoError(t, err)
+	assert.Equal(t, `[`+
+		`["10857046999023057135944570762232829481370756359578518086990519993285655852781",`+
+		`"11559732032986387107991004021392285783925812861821192530917403151452391805634"],`+
+		`["8495653923123431417604973247489272438418190587263600148770280649306958101930",`+
+		`"4082367875863433681332203403145435568316851327593401208105741076214120093531"],`+
+		`["1","0"]]`, string(raw))
+
+	var g2Snarkjs [][]string
+
+	require.NoError(t, json.Unmarshal(raw, &g2Snarkjs))
+
+	g2, err := G2FromSnarkjs(g2Snarkjs)
+	require.NoError(t, err)
+	assert.True(t, ellipticCurveG2.IsEqual(g2))
+
+	g1Res, err := G1FromSnarkjs([]string{"1", "2", "1"})
+	require.NoError(t, err)
+	assert.True(t, g1.IsEqual(g1Res))
+}
+
+func Test_SnarkjsInfinity(t *testing.T) {
+	t.Parallel()
+
+	g1Snarkjs := G1ToSnarkjs(new(G1))
+	assert.Equal(t, []string{"0", "1", "0"}, g1Snarkjs)
+
+	g1, err := G1FromSnarkjs(g1Snarkjs)
+	require.NoError(t, err)
+	assert.True(t, g1.IsZero())
+
+	g2Snarkjs := G2ToSnarkjs(new(G2))
+	assert.Equal(t, [][]string{{"0", "0"}, {"1", "0"}, {"0", "0"}}, g2Snarkjs)
+
+	g2, err := G2FromSnarkjs(g2Snarkjs)
+	require.NoError(t, err)
+	assert.True(t, g2.IsZero())
+}
+
+func Test_SnarkjsFrAndGT(t *testing.T) {
+	t.Parallel()
+
+	x := new(Fr)
+	require.True(t, x.SetByCSPRNG())
+
+	xRes, err := FrFromSnarkjs(FrToSnarkjs(x))
+	require.NoError(t, err)
+	assert.True(t, x.IsEqual(xRes))
+
+	g1 := new(G1)
+	require.NoError(t, g1.SetString("1 1 2", 10))
+
+	e := new(GT)
+	Pairing(e, g1, ellipticCurveG2)
+
+	raw, err := json.Marshal(GTToSnarkjs(e))
+	require.NoError(t, err)
+
+	var gtSnarkjs [][][]string
+
+	require.NoError(t, json.Unmarshal(raw, &gtSnarkjs))
+	require.Len(t, gtSnarkjs, 2)
+	require.Len(t, gtSnarkjs[1], 3)
+	require.Len(t, gtSnarkjs[1][2], 2)
+
+	eRes, err := GTFromSnarkjs(gtSnarkjs)
+	require.NoError(t, err)
+	assert.True(t, e.IsEqual(eRes))
+}
+
+func Test_SnarkjsInvalid(t *testing.T) {
+	t.Parallel()
+
+	invalid := [][]string{
+		{"1", "2"},
+		{"1", "3", "1"},
+		{"1", "2", "2"},
+		{"-1", "2", "1"},
+		{" 1", "2", "1"},
+		{"", "2", "1"},
+		{"0x1", "2", "1"},
+		{"21888242871839275222246405745257275088696311157297823662689037894645226208584", "2", "1"},
+	}
+
+	for _, v := range invalid {
+		_, err := G1FromSnarkjs(v)
+		assert.Error(t, err, v)
+	}
+
+	_, err := G1FromSnarkjs([]string{"1", "3", "1"})
+	assert.ErrorIs(t, err, ErrNotOnCurve)
+
+	_, err = G2FromSnarkjs([][]string{{"1", "2"}, {"1"}, {"1", "0"}})
+	assert.Error(t, err)
+
+	_, err = FrFromSnarkjs("21888242871839275222246405745257275088548364400416034343698204186575808495617")
+	assert.ErrorIs(t, err, ErrNonCanonical)
+
+	_, err = GTFromSnarkjs([][][]string{{{"1", "0"}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+//! Prints arkworks encodings of the interop vectors for `go run . -arkworks`, one value per line:
+//! `group<TAB>name<TAB>uncompressed hex[<TAB>compressed hex]`
+
+use ark_bn254::{Fr, G1Affine, G2Affine};
+use ark_ec::{AffineRepr, CurveGroup};
+use ark_ff::{One, Zero};
+use ark_serialize::CanonicalSerialize;
+
+fn hex<T: CanonicalSerialize>(value: &T, compressed: bool) -> String {
+    let mut buf = Vec::new();
+    if compressed {
+        value.serialize_compressed(&mut buf).unwrap();
+    } else {
+        value.serialize_uncompressed(&mut buf).unwrap();
+    }
+    buf.iter().map(|b| format!("{:02x}", b)).collect()
+}
+
+fn print_point<T: AffineRepr + CanonicalSerialize>(group: &str, name: &str, p: &T) {
+    println!("{}\t{}\t{}\t{}", group, name, hex(p, false), hex(p, true));
+}
+
+fn main() {
+    let g1 = G1Affine::generator();
+    print_point("g1", "generator", &g1);
+    print_point("g1", "negated generator", &(-g1));
+    print_point("g1", "doubled generator", &(g1 + g1).into_affine());
+    print_point("g1", "infinity", &G1Affine::zero());
+
+    let g2 = G2Affine::generator();
+    print_point("g2", "generator", &g2);
+    print_point("g2", "negated generator", &(-g2));
+    print_point("g2", "doubled generator", &(g2 + g2).into_affine());
+    print_point("g2", "infinity", &G2Affine::zero());
+
+    for x in [Fr::zero(), Fr::one(), Fr::from(12345u64), -Fr::one()] {
+        println!("fr\t{}\t{}", x, hex(&x, false));
+    }
+}
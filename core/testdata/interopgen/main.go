@@ -0,0 +1,234 @@
+// Command interopgen regenerates ../interop_vectors.json with the reference libraries.
+//
+// gnark-crypto encodings are produced here with the version pinned in go.mod. arkworks encodings are
+// produced by the program in the arkworks directory with the versions pinned in its Cargo.toml:
+//
+//	cargo run --release --manifest-path arkworks/Cargo.toml > arkworks.tsv
+//	go run . -arkworks arkworks.tsv
+//
+// -arkworks is required, so the arkworks entries are never carried over from an earlier file
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+type vector struct {
+	Name               string `json:"name"`
+	X                  string `json:"x"`
+	Y                  string `json:"y"`
+	Gnark              string `json:"gnark"`
+	GnarkCompressed    string `json:"gnarkCompressed"`
+	Arkworks           string `json:"arkworks"`
+	ArkworksCompressed string `json:"arkworksCompressed"`
+}
+
+type vectors struct {
+	G1 []vector   `json:"g1"`
+	G2 []vector   `json:"g2"`
+	Fr []frVector `json:"fr"`
+}
+
+type frVector struct {
+	Value    string `json:"value"`
+	Gnark    string `json:"gnark"`
+	Arkworks string `json:"arkworks"`
+}
+
+func main() {
+	out := flag.String("out", "../interop_vectors.json", "vectors file to rewrite")
+	arkworksFile := flag.String("arkworks", "", "output of the arkworks generator")
+	flag.Parse()
+
+	if err := run(*out, *arkworksFile); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(out, arkworksFile string) error {
+	arkworks, err := loadArkworks(arkworksFile)
+	if err != nil {
+		return err
+	}
+
+	res := new(vectors)
+
+	_, _, g1Gen, g2Gen := bn254.Generators()
+
+	var g1Neg, g1Double, g1Inf bn254.G1Affine
+
+	g1Neg.Neg(&g1Gen)
+	g1Double.Add(&g1Gen, &g1Gen)
+
+	for _, c := range []struct {
+		name string
+		p    *bn254.G1Affine
+	}{
+		{"generator", &g1Gen}, {"negated generator", &g1Neg}, {"doubled generator", &g1Double}, {"infinity", &g1Inf},
+	} {
+		v := vector{Name: c.name}
+		if !c.p.IsInfinity() {
+			v.X, v.Y = decimal(&c.p.X), decimal(&c.p.Y)
+		}
+
+		raw, compressed := c.p.RawBytes(), c.p.Bytes()
+		v.Gnark, v.GnarkCompressed = hex.EncodeToString(raw[:]), hex.EncodeToString(compressed[:])
+
+		if err := checkG1(c.p, raw[:], compressed[:]); err != nil {
+			return fmt.Errorf("g1 %s: %w", c.name, err)
+		}
+
+		if err := setArkworks(&v.Arkworks, &v.ArkworksCompressed, arkworks, "g1\t"+c.name); err != nil {
+			return err
+		}
+
+		res.G1 = append(res.G1, v)
+	}
+
+	var g2Neg, g2Double, g2Inf bn254.G2Affine
+
+	g2Neg.Neg(&g2Gen)
+	g2Double.Add(&g2Gen, &g2Gen)
+
+	for _, c := range []struct {
+		name string
+		p    *bn254.G2Affine
+	}{
+		{"generator", &g2Gen}, {"negated generator", &g2Neg}, {"doubled generator", &g2Double}, {"infinity", &g2Inf},
+	} {
+		v := vector{Name: c.name}
+		if !c.p.IsInfinity() {
+			v.X = decimal(&c.p.X.A0) + " " + decimal(&c.p.X.A1)
+			v.Y = decimal(&c.p.Y.A0) + " " + decimal(&c.p.Y.A1)
+		}
+
+		raw, compressed := c.p.RawBytes(), c.p.Bytes()
+		v.Gnark, v.GnarkCompressed = hex.EncodeToString(raw[:]), hex.EncodeToString(compressed[:])
+
+		if err := checkG2(c.p, raw[:], compressed[:]); err != nil {
+			return fmt.Errorf("g2 %s: %w", c.name, err)
+		}
+
+		if err := setArkworks(&v.Arkworks, &v.ArkworksCompressed, arkworks, "g2\t"+c.name); err != nil {
+			return err
+		}
+
+		res.G2 = append(res.G2, v)
+	}
+
+	order := fr.Modulus()
+
+	for _, x := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(12345), order.Sub(order, big.NewInt(1))} {
+		var e fr.Element
+
+		e.SetBigInt(x)
+
+		raw := e.Bytes()
+		v := frVector{Value: x.String(), Gnark: hex.EncodeToString(raw[:])}
+
+		if err := setArkworks(&v.Arkworks, nil, arkworks, "fr\t"+v.Value); err != nil {
+			return err
+		}
+
+		res.Fr = append(res.Fr, v)
+	}
+
+	raw, err := json.MarshalIndent(res, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(out, append(raw, '\n'), 0o600)
+}
+
+// decimal returns the canonical decimal form, fp.Element.String prints values close to p as negative
+func decimal(x *fp.Element) string {
+	return x.BigInt(new(big.Int)).String()
+}
+
+// checkG1 checks that gnark-crypto decodes both of its encodings back to the point
+func checkG1(p *bn254.G1Affine, raw, compressed []byte) error {
+	for _, buf := range [][]byte{raw, compressed} {
+		var q bn254.G1Affine
+
+		if _, err := q.SetBytes(buf); err != nil {
+			return err
+		}
+
+		if !q.Equal(p) {
+			return fmt.Errorf("decoded %x to another point", buf)
+		}
+	}
+
+	return nil
+}
+
+// checkG2 checks that gnark-crypto decodes both of its encodings back to the point
+func checkG2(p *bn254.G2Affine, raw, compressed []byte) error {
+	for _, buf := range [][]byte{raw, compressed} {
+		var q bn254.G2Affine
+
+		if _, err := q.SetBytes(buf); err != nil {
+			return err
+		}
+
+		if !q.Equal(p) {
+			return fmt.Errorf("decoded %x to another point", buf)
+		}
+	}
+
+	return nil
+}
+
+// loadArkworks returns arkworks encodings keyed by "group\tname" from the output of the arkworks generator
+func loadArkworks(arkworksFile string) (map[string][]string, error) {
+	if arkworksFile == "" {
+		return nil, fmt.Errorf("-arkworks is required, run the arkworks generator first")
+	}
+
+	f, err := os.Open(arkworksFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	res := make(map[string][]string)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid arkworks line %q", scanner.Text())
+		}
+
+		res[fields[0]+"\t"+fields[1]] = fields[2:]
+	}
+
+	return res, scanner.Err()
+}
+
+func setArkworks(uncompressed, compressed *string, arkworks map[string][]string, key string) error {
+	values, ok := arkworks[key]
+	if !ok || (compressed != nil && len(values) < 2) {
+		return fmt.Errorf("no arkworks encoding of %q", key)
+	}
+
+	*uncompressed = values[0]
+	if compressed != nil {
+		*compressed = values[1]
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+// Command precompilegen checks ../precompile_vectors.json against the bn256 precompiles of go-ethereum
+// and copies the precompile test data of go-ethereum to ../geth. The version of go-ethereum is pinned
+// in go.mod:
+//
+//	go run .
+//
+// Expected outputs of the edge cases are rewritten with the outputs of go-ethereum, vectors with
+// an error must fail in go-ethereum as well
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+type vector struct {
+	Name     string `json:"name"`
+	Input    string `json:"input"`
+	Expected string `json:"expected,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type vectors struct {
+	Add     []vector `json:"add"`
+	Mul     []vector `json:"mul"`
+	Pairing []vector `json:"pairing"`
+}
+
+// gethFiles are copied from core/vm/testdata/precompiles of go-ethereum
+var gethFiles = []string{"bn256Add.json", "bn256ScalarMul.json", "bn256Pairing.json"}
+
+func main() {
+	out := flag.String("out", "..", "testdata directory")
+	flag.Parse()
+
+	if err := run(*out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(out string) error {
+	dir, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "github.com/ethereum/go-ethereum").Output()
+	if err != nil {
+		return fmt.Errorf("go-ethereum module: %w", err)
+	}
+
+	for _, name := range gethFiles {
+		raw, err := os.ReadFile(filepath.Join(strings.TrimSpace(string(dir)), "core", "vm", "testdata", "precompiles", name))
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(filepath.Join(out, "geth", name), raw, 0o600); err != nil {
+			return err
+		}
+	}
+
+	path := filepath.Join(out, "precompile_vectors.json")
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	res := new(vectors)
+	if err := json.Unmarshal(raw, res); err != nil {
+		return err
+	}
+
+	for _, c := range []struct {
+		address byte
+		vectors []vector
+	}{
+		{6, res.Add}, {7, res.Mul}, {8, res.Pairing},
+	} {
+		precompile := vm.PrecompiledContractsIstanbul[common.BytesToAddress([]byte{c.address})]
+
+		for i := range c.vectors {
+			v := &c.vectors[i]
+
+			input, err := hex.DecodeString(v.Input)
+			if err != nil {
+				return fmt.Errorf("%s: %w", v.Name, err)
+			}
+
+			output, err := precompile.Run(input)
+
+			switch {
+			case v.Error != "" && err == nil:
+				return fmt.Errorf("%s: go-ethereum accepts the input", v.Name)
+			case v.Error == "" && err != nil:
+				return fmt.Errorf("%s: go-ethereum rejects the input: %w", v.Name, err)
+			case err == nil:
+				v.Expected = hex.EncodeToString(output)
+			}
+		}
+	}
+
+	raw, err = json.MarshalIndent(res, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(raw, '\n'), 0o600)
+}
@@ -0,0 +1,168 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GTCompressedSize is the size of torus-compressed GT element, a half of GT.Serialize
+const GTCompressedSize = 6 * 32
+
+// gtCompressedOne is set in the last byte of the compressed identity of GT. It never
+// appears in compressed non identity elements as the field modulus is less than 2^255
+const gtCompressedOne byte = 1 << 7
+
+// ErrInvalidGT is returned when decoded value is not an element of GT
+var ErrInvalidGT = errors.New("invalid GT element")
+
+// Fp6 -- D[0] + D[1] v + D[2] v^2 where v^3 = xi
+type Fp6 struct {
+	D [3]Fp2
+}
+
+// Fp12 -- D[0] + D[1] w where w^2 = v. It has the same layout as GT
+type Fp12 struct {
+	D [2]Fp6
+}
+
+// SetString -- accepts "<a> <b>" which stands for a + b i, the format of GetString
+func (x *Fp2) SetString(s string, base int) error {
+	parts := strings.Fields(s)
+	if len(parts) != 2 {
+		return fmt.Errorf("err Fp2.SetString expect 2 coefficients but got %d", len(parts))
+	}
+
+	var res Fp2
+
+	for i := range parts {
+		if err := res.D[i].SetString(parts[i], base); err != nil {
+			return fmt.Errorf("err Fp2.SetString %w", err)
+		}
+	}
+
+	*x = res
+
+	return nil
+}
+
+// GetString --
+func (x *Fp2) GetString(base int) string {
+	return x.D[0].GetString(base) + " " + x.D[1].GetString(base)
+}
+
+// SetByCSPRNG --
+func (x *Fp2) SetByCSPRNG() {
+	x.D[0].SetByCSPRNG()
+	x.D[1].SetByCSPRNG()
+}
+
+// IsValid -- both coefficients are less than the field modulus
+func (x *Fp2) IsValid() bool {
+	return x.D[0].IsValid() && x.D[1].IsValid()
+}
+
+// IsValidOrder -- x^r = 1
+func (x *GT) IsValidOrder() bool {
+	var e Fr
+
+	// x^r = x^(r - 1) x
+	e.SetInt64(-1)
+
+	var t GT
+
+	GTPowGeneric(&t, x, &e)
+	GTMul(&t, &t, x)
+
+	return t.IsOne()
+}
+
+// SerializeCompressed encodes x = (a + b w) from GT as c = (1 + a) / b in Fp6, so x = (c + w) / (c - w).
+// The encoding works for all elements of norm one, which include GT
+func (x *GT) SerializeCompressed() ([]byte, error) {
+	buf := make([]byte, GTCompressedSize)
+
+	if x.IsOne() {
+		buf[GTCompressedSize-1] = gtCompressedOne
+
+		return buf, nil
+	}
+
+	var norm GT
+
+	// GTInv is the conjugate of x, so the norm is x^(p^6 + 1)
+	GTInv(&norm, x)
+	GTMul(&norm, &norm, x)
+
+	if !norm.IsOne() {
+		return nil, fmt.Errorf("%w: norm is not one", ErrInvalidGT)
+	}
+
+	f := x.GetFp12()
+
+	var a, b, one GT
+
+	a.SetFp12(&Fp12{D: [2]Fp6{f.D[0]}})
+	b.SetFp12(&Fp12{D: [2]Fp6{f.D[1]}})
+	one.SetInt64(1)
+
+	// b = 0 implies x = -1 which is encoded as c = 0
+	if !b.IsZero() {
+		GTAdd(&a, &a, &one)
+		GTDiv(&a, &a, &b)
+
+		c := a.GetFp12()
+
+		for i, y := range gtCompressedCoefficients(&c.D[0]) {
+			copy(buf[i*32:], y.Serialize())
+		}
+	}
+
+	return buf, nil
+}
+
+// DeserializeCompressed decodes the output of SerializeCompressed and checks the result is in GT
+func (x *GT) DeserializeCompressed(buf []byte) error {
+	if len(buf) != GTCompressedSize {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidGT, GTCompressedSize, len(buf))
+	}
+
+	if buf[GTCompressedSize-1] == gtCompressedOne && isAllZero(buf[:GTCompressedSize-1]) {
+		x.SetInt64(1)
+
+		return nil
+	}
+
+	var c, w Fp12
+
+	for i, y := range gtCompressedCoefficients(&c.D[0]) {
+		if err := fpFromLittleEndian(y, buf[i*32:(i+1)*32]); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidGT, err)
+		}
+	}
+
+	w.D[1].D[0].D[0].SetInt64(1)
+
+	var cg, wg, num, den, res GT
+
+	cg.SetFp12(&c)
+	wg.SetFp12(&w)
+
+	// c^2 - v is never zero as v is not a square in Fp6
+	GTAdd(&num, &cg, &wg)
+	GTSub(&den, &cg, &wg)
+	GTDiv(&res, &num, &den)
+
+	if !res.IsValidOrder() {
+		return fmt.Errorf("%w: %x", ErrInvalidGT, buf)
+	}
+
+	*x = res
+
+	return nil
+}
+
+// gtCompressedCoefficients returns Fp coefficients of x in the order of the compressed encoding
+func gtCompressedCoefficients(x *Fp6) []*Fp {
+	return []*Fp{&x.D[0].D[0], &x.D[0].D[1], &x.D[1].D[0], &x.D[1].D[1], &x.D[2].D[0], &x.D[2].D[1]}
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Fp2String(t *testing.T) {
+	t.Parallel()
+
+	var x, y Fp2
+
+	x.SetByCSPRNG()
+	assert.True(t, x.IsValid())
+
+	for _, base := range []int{10, 16} {
+		require.NoError(t, y.SetString(x.GetString(base), base))
+		assert.True(t, x.IsEqual(&y))
+	}
+
+	require.NoError(t, y.SetString("1 2", 10))
+	assert.Equal(t, "1", y.D[0].GetString(10))
+	assert.Equal(t, "2", y.D[1].GetString(10))
+
+	assert.Error(t, y.SetString("1", 10))
+	assert.Error(t, y.SetString("1 2 3", 10))
+	assert.Error(t, y.SetString("1 x", 10))
+	assert.Equal(t, "1 2", y.GetString(10))
+}
+
+func Test_GTFp12(t *testing.T) {
+	t.Parallel()
+
+	e := testRandomGT(t)
+	f := e.GetFp12()
+
+	// coefficients follow GetString order
+	parts := strings.Fields(e.GetString(10))
+	for i := range f.D {
+		for j := range f.D[i].D {
+			for k := range f.D[i].D[j].D {
+				assert.Equal(t, parts[i*6+j*2+k], f.D[i].D[j].D[k].GetString(10))
+			}
+		}
+	}
+
+	var e2 GT
+
+	e2.SetFp12(&f)
+	assert.True(t, e.IsEqual(&e2))
+}
+
+func Test_GTCompressed(t *testing.T) {
+	t.Parallel()
+
+	var one GT
+
+	one.SetInt64(1)
+
+	for _, e := range []*GT{testRandomGT(t), testRandomGT(t), &one} {
+		buf, err := e.SerializeCompressed()
+		require.NoError(t, err)
+		assert.Len(t, buf, GTCompressedSize)
+
+		var e2 GT
+
+		require.NoError(t, e2.DeserializeCompressed(buf))
+		assert.True(t, e.IsEqual(&e2))
+	}
+}
+
+func Test_GTCompressedInvalid(t *testing.T) {
+	t.Parallel()
+
+	var x GT
+
+	// random element of Fp12 does not have norm one
+	var f Fp12
+
+	f.D[0].D[0].SetByCSPRNG()
+	f.D[1].D[2].SetByCSPRNG()
+	x.SetFp12(&f)
+
+	_, err := x.SerializeCompressed()
+	assert.ErrorIs(t, err, ErrInvalidGT)
+
+	// -1 has norm one but it is not in GT
+	x.SetInt64(-1)
+
+	buf, err := x.SerializeCompressed()
+	require.NoError(t, err)
+	assert.ErrorIs(t, x.DeserializeCompressed(buf), ErrInvalidGT)
+
+	// random Fp6 decodes to norm one element which is not in GT
+	for i := 0; i < GTCompressedSize; i += 32 {
+		var y Fp
+
+		y.SetByCSPRNG()
+		copy(buf[i:], y.Serialize())
+	}
+
+	assert.ErrorIs(t, x.DeserializeCompressed(buf), ErrInvalidGT)
+	assert.ErrorIs(t, x.DeserializeCompressed(buf[1:]), ErrInvalidGT)
+
+	// non-canonical coefficient
+	for i := range buf[:32] {
+		buf[i] = 0xff
+	}
+
+	assert.ErrorIs(t, x.DeserializeCompressed(buf), ErrInvalidGT)
+}
+
+func testRandomGT(t *testing.T) *GT {
+	t.Helper()
+
+	var (
+		x Fr
+		p G1
+		e GT
+	)
+
+	require.True(t, x.SetByCSPRNG())
+	G1Mul(&p, ellipticCurveG1, &x)
+	Pairing(&e, &p, ellipticCurveG2)
+
+	return &e
+}
@@ -2,7 +2,10 @@ package core
 
 import (
 	"crypto/sha256"
+	"encoding/binary"
 	"errors"
+	"hash"
+	"sync"
 )
 
 // CreateRandomBlsKeys creates an slice of random private keys
@@ -23,8 +26,12 @@ func CreateRandomBlsKeys(total int) ([]*PrivateKey, error) {
 
 // MarshalMessage marshalls message into byte slice
 func MarshalMessage(message []byte) ([]byte, error) {
-	g1, err := HashToG1(message)
-	if err != nil {
+	g1 := new(G1)
+
+	s := hashScratchPool.Get().(*hashScratch)
+	defer hashScratchPool.Put(s)
+
+	if err := hashToG1Into(g1, message, s); err != nil {
 		return nil, err
 	}
 
@@ -43,160 +50,197 @@ func HashToG103(message []byte) (*G1, error) {
 
 // HashToG107 converts message to G1 point https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-hash-to-curve-07
 func HashToG107(message []byte) (*G1, error) {
-	hashRes, err := hashToFpXMDSHA256(message, GetDomain(), 2)
-	if err != nil {
+	p := new(G1)
+
+	s := hashScratchPool.Get().(*hashScratch)
+	defer hashScratchPool.Put(s)
+
+	if err := s.hashToG107(p, message); err != nil {
 		return nil, err
 	}
 
-	p0, p1 := new(G1), new(G1)
-	u0, u1 := hashRes[0], hashRes[1]
+	return p, nil
+}
 
-	if err := MapToG1(p0, u0); err != nil {
-		return nil, err
+// hashToG1Into sets out to HashToG1(message). The default HashToG107 is computed
+// in place with pooled scratch space, so it does not allocate, and its points are
+// cached if the cache is enabled by SetHashCacheSize
+func hashToG1Into(out *G1, message []byte, s *hashScratch) error {
+	return hashToG1DomainInto(out, message, GetDomain(), s)
+}
+
+// hashToG1DomainInto is hashToG1Into with the given domain separation tag of the default
+// HashToG107, a function set by SetHashToG1 gets the message only
+func hashToG1DomainInto(out *G1, message []byte, domain []byte, s *hashScratch) error {
+	if customHashToG1 != nil {
+		p, err := customHashToG1(message)
+		if err != nil {
+			return err
+		}
+
+		*out = *p
+
+		return nil
 	}
 
-	if err := MapToG1(p1, u1); err != nil {
-		return nil, err
+	if !hashCache.enabled() {
+		return s.hashToG107Domain(out, message, domain)
 	}
 
-	G1Add(p0, p0, p1)
-	G1Normalize(p0, p0)
+	s.cacheKey(message, domain)
 
-	return p0, nil
-}
+	if hashCache.get(&s.key, out) {
+		return nil
+	}
 
-func hashToFpXMDSHA256(msg []byte, domain []byte, count int) ([]*Fp, error) {
-	randBytes, err := expandMsgSHA256XMD(msg, domain, count*48)
-	if err != nil {
-		return nil, err
+	if err := s.hashToG107Domain(out, message, domain); err != nil {
+		return err
 	}
 
-	els := make([]*Fp, count)
+	hashCache.add(&s.key, out)
 
-	for i := 0; i < count; i++ {
-		els[i], err = from48Bytes(randBytes[i*48 : (i+1)*48])
-		if err != nil {
-			return nil, err
-		}
+	return nil
+}
+
+var (
+	hashScratchPool = sync.Pool{
+		New: func() interface{} {
+			return &hashScratch{h: sha256.New()}
+		},
 	}
 
-	return els, nil
+	// zeroBlock is Z_pad of expand_message_xmd
+	zeroBlock [sha256.BlockSize]byte
+)
+
+// hashScratch holds buffers and temporary values of HashToG107. Values passed to the
+// backend escape to the heap, so they are kept here instead of the stack
+type hashScratch struct {
+	h       hash.Hash
+	b0, bi  [sha256.Size]byte
+	tmp     [sha256.Size]byte
+	octet   [3]byte
+	uniform [2 * 48]byte
+	u       [2]Fp
+	e       Fp
+	p       G1
+	key     [sha256.Size]byte
 }
 
-func expandMsgSHA256XMD(msg []byte, domain []byte, outLen int) ([]byte, error) {
-	h := sha256.New()
+// hashToG107 sets out to the sum of two mapped field elements hashed from the message
+func (s *hashScratch) hashToG107(out *G1, message []byte) error {
+	return s.hashToG107Domain(out, message, GetDomain())
+}
+
+// hashToG107Domain is hashToG107 with the given domain separation tag
+func (s *hashScratch) hashToG107Domain(out *G1, message []byte, domain []byte) error {
+	if err := s.expandMsgSHA256XMD(s.uniform[:], message, domain); err != nil {
+		return err
+	}
+
+	for i := range s.u {
+		s.from48Bytes(&s.u[i], s.uniform[i*48:(i+1)*48])
+	}
+
+	if err := MapToG1(out, &s.u[0]); err != nil {
+		return err
+	}
 
+	if err := MapToG1(&s.p, &s.u[1]); err != nil {
+		return err
+	}
+
+	G1Add(out, out, &s.p)
+	G1Normalize(out, out)
+
+	return nil
+}
+
+// expandMsgSHA256XMD fills out with expand_message_xmd(msg, domain, len(out)) using SHA-256
+func (s *hashScratch) expandMsgSHA256XMD(out []byte, msg []byte, domain []byte) error {
 	if len(domain) > 255 {
-		return nil, errors.New("invalid domain length")
+		return errors.New("invalid domain length")
 	}
 
+	h := s.h
+	outLen := len(out)
 	domainLen := uint8(len(domain))
+
 	// DST_prime = DST || I2OSP(len(DST), 1)
 	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
-	_, _ = h.Write(make([]byte, h.BlockSize()))
+	h.Reset()
+	_, _ = h.Write(zeroBlock[:])
 	_, _ = h.Write(msg)
-	_, _ = h.Write([]byte{uint8(outLen >> 8), uint8(outLen)})
-	_, _ = h.Write([]byte{0})
-	_, _ = h.Write(domain)
-	_, _ = h.Write([]byte{domainLen})
-	b0 := h.Sum(nil)
+	s.octet = [3]byte{uint8(outLen >> 8), uint8(outLen), 0}
+	_, _ = h.Write(s.octet[:3])
+	s.writeDomain(domain, domainLen)
+	b0 := h.Sum(s.b0[:0])
 
 	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
 	h.Reset()
 	_, _ = h.Write(b0)
-	_, _ = h.Write([]byte{1})
-	_, _ = h.Write(domain)
-	_, _ = h.Write([]byte{domainLen})
-	b1 := h.Sum(nil)
-
-	// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
-	ell := (outLen + h.Size() - 1) / h.Size()
-	bi := b1
-	out := make([]byte, outLen)
+	s.octet[0] = 1
+	_, _ = h.Write(s.octet[:1])
+	s.writeDomain(domain, domainLen)
+	bi := h.Sum(s.bi[:0])
+
+	ell := (outLen + sha256.Size - 1) / sha256.Size
 	for i := 1; i < ell; i++ {
-		h.Reset()
+		// b_1 || ... || b_(ell - 1)
+		copy(out[(i-1)*sha256.Size:i*sha256.Size], bi)
+
 		// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
-		tmp := make([]byte, h.Size())
-		for j := 0; j < h.Size(); j++ {
-			tmp[j] = b0[j] ^ bi[j]
+		for j := range s.tmp {
+			s.tmp[j] = b0[j] ^ bi[j]
 		}
-		_, _ = h.Write(tmp)
-		_, _ = h.Write([]byte{1 + uint8(i)})
-		_, _ = h.Write(domain)
-		_, _ = h.Write([]byte{domainLen})
 
-		// b_1 || ... || b_(ell - 1)
-		copy(out[(i-1)*h.Size():i*h.Size()], bi[:])
-		bi = h.Sum(nil)
+		h.Reset()
+		_, _ = h.Write(s.tmp[:])
+		s.octet[0] = 1 + uint8(i)
+		_, _ = h.Write(s.octet[:1])
+		s.writeDomain(domain, domainLen)
+		bi = h.Sum(s.bi[:0])
 	}
 
 	// b_ell
-	copy(out[(ell-1)*h.Size():], bi[:])
+	copy(out[(ell-1)*sha256.Size:], bi)
 
-	return out[:outLen], nil
+	return nil
 }
 
-func fpFromBytes(in []byte) (*Fp, error) {
-	const size = 32
-
-	if len(in) != size {
-		return nil, errors.New("input string should be equal 32 bytes")
-	}
-
-	l := len(in)
-	if l >= size {
-		l = size
-	}
-
-	padded := make([]byte, size)
+// writeDomain writes DST_prime to the hasher
+func (s *hashScratch) writeDomain(domain []byte, domainLen uint8) {
+	_, _ = s.h.Write(domain)
+	s.octet[0] = domainLen
+	_, _ = s.h.Write(s.octet[:1])
+}
 
-	copy(padded[size-l:], in[:])
+// fpFromBytes sets out to 32 bytes big-endian integer reduced modulo p
+func fpFromBytes(out *Fp, in []byte) {
+	const size = 32
 
 	component := [4]uint64{}
 
 	for i := 0; i < 4; i++ {
 		a := size - i*8
-		component[i] = uint64(padded[a-1]) | uint64(padded[a-2])<<8 |
-			uint64(padded[a-3])<<16 | uint64(padded[a-4])<<24 |
-			uint64(padded[a-5])<<32 | uint64(padded[a-6])<<40 |
-			uint64(padded[a-7])<<48 | uint64(padded[a-8])<<56
+		component[i] = binary.BigEndian.Uint64(in[a-8 : a])
 	}
 
-	fe := newFp(component[0], component[1], component[2], component[3])
+	*out = newFp(component[0], component[1], component[2], component[3])
 
-	FpMul(&fe, &fe, &r2)
-
-	return &fe, nil
+	FpMul(out, out, &r2)
 }
 
-func from48Bytes(in []byte) (*Fp, error) {
-	if len(in) != 48 {
-		return nil, errors.New("input string should be equal 48 bytes")
-	}
-
-	a0 := make([]byte, 32)
-	copy(a0[8:32], in[:24])
-	a1 := make([]byte, 32)
-	copy(a1[8:32], in[24:])
-
-	e0, err := fpFromBytes(a0)
-	if err != nil {
-		return nil, err
-	}
-
-	e1, err := fpFromBytes(a1)
-	if err != nil {
-		return nil, err
-	}
+// from48Bytes sets out to 48 bytes big-endian integer reduced modulo p
+func (s *hashScratch) from48Bytes(out *Fp, in []byte) {
+	var a [32]byte
 
-	// F = 2 ^ 192 * R
-	F := newFp(0xd9e291c2cdd22cd6,
-		0xc722ccf2a40f0271,
-		0xa49e35d611a2ac87,
-		0x2e1043978c993ec8)
+	copy(a[8:], in[:24])
+	fpFromBytes(&s.e, a[:])
 
-	FpMul(e0, e0, &F)
-	FpAdd(e1, e1, e0)
+	copy(a[8:], in[24:])
+	fpFromBytes(out, a[:])
 
-	return e1, nil
+	FpMul(&s.e, &s.e, &f192)
+	FpAdd(out, out, &s.e)
 }
@@ -77,3 +77,54 @@ func Test_MarshalMessageToBigInt(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, bytes, 64)
 }
+
+// Test_SetHashToG1 replaces the global hash function, so it must not run in parallel with other tests
+func Test_SetHashToG1(t *testing.T) {
+	defer SetHashToG1(nil)
+
+	validTestMsg := testGenRandomBytes(t, messageSize)
+
+	blsKey, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	calls := 0
+
+	// a closure wrapping a function of the package is a custom one
+	SetHashToG1(func(message []byte) (*G1, error) {
+		calls++
+
+		return HashToG103(message)
+	})
+
+	expected, err := HashToG103(validTestMsg)
+	require.NoError(t, err)
+
+	marshaled, err := MarshalMessage(validTestMsg)
+	require.NoError(t, err)
+	assert.Equal(t, G1ToBytes(expected), marshaled)
+
+	signature, err := blsKey.Sign(validTestMsg)
+	require.NoError(t, err)
+	assert.True(t, signature.Verify(blsKey.PublicKey(), validTestMsg))
+	assert.True(t, NewVerifier().Verify(signature, blsKey.PublicKey(), validTestMsg))
+
+	g1, err := HashToG1(validTestMsg)
+	require.NoError(t, err)
+	assert.True(t, expected.IsEqual(g1))
+	assert.Equal(t, 5, calls)
+
+	SetHashToG1(nil)
+
+	expected, err = HashToG107(validTestMsg)
+	require.NoError(t, err)
+
+	marshaled, err = MarshalMessage(validTestMsg)
+	require.NoError(t, err)
+	assert.Equal(t, G1ToBytes(expected), marshaled)
+	assert.False(t, signature.Verify(blsKey.PublicKey(), validTestMsg))
+
+	g1, err = HashToG1(validTestMsg)
+	require.NoError(t, err)
+	assert.True(t, expected.IsEqual(g1))
+	assert.Equal(t, 5, calls)
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGenRandomFrs(t testing.TB, n int) []Fr {
+	t.Helper()
+
+	res := make([]Fr, n)
+
+	for i := range res {
+		require.True(t, res[i].SetByCSPRNG())
+	}
+
+	return res
+}
+
+func Test_FrVec(t *testing.T) {
+	t.Parallel()
+
+	const n = 17
+
+	x, y := testGenRandomFrs(t, n), testGenRandomFrs(t, n)
+	out := make([]Fr, n)
+
+	var expected Fr
+
+	for _, c := range []struct {
+		name   string
+		vec    func(out, x, y []Fr)
+		scalar func(out, x, y *Fr)
+	}{
+		{"add", FrAddVec, FrAdd},
+		{"sub", FrSubVec, FrSub},
+		{"mul", FrMulVec, FrMul},
+	} {
+		c.vec(out, x, y)
+
+		for i := range out {
+			c.scalar(&expected, &x[i], &y[i])
+			assert.True(t, expected.IsEqual(&out[i]), "%s %d", c.name, i)
+		}
+
+		// out may alias the input
+		aliased := append([]Fr{}, x...)
+		c.vec(aliased, aliased, y)
+		assert.Equal(t, out, aliased, c.name)
+
+		assert.Panics(t, func() { c.vec(out[1:], x, y) }, c.name)
+		assert.NotPanics(t, func() { c.vec(nil, nil, nil) }, c.name)
+	}
+}
+
+func Test_BatchInv(t *testing.T) {
+	t.Parallel()
+
+	x := testGenRandomFrs(t, 9)
+	x[0].Clear()
+	x[4].Clear()
+
+	out := make([]Fr, len(x))
+
+	var expected Fr
+
+	FrBatchInv(out, x)
+
+	for i := range out {
+		if x[i].IsZero() {
+			assert.True(t, out[i].IsZero(), "fr %d", i)
+
+			continue
+		}
+
+		FrInv(&expected, &x[i])
+		assert.True(t, expected.IsEqual(&out[i]), "fr %d", i)
+	}
+
+	FrBatchInv(x, x)
+	assert.Equal(t, out, x)
+
+	// all zeros and a single element
+	zeros := make([]Fr, 3)
+	FrBatchInv(zeros, zeros)
+	assert.Equal(t, make([]Fr, 3), zeros)
+
+	FrBatchInv(out[:1], x[1:2])
+	FrInv(&expected, &x[1])
+	assert.True(t, expected.IsEqual(&out[0]))
+
+	fps := make([]Fp, 5)
+	for i := range fps {
+		fps[i].SetByCSPRNG()
+	}
+
+	fps[3].Clear()
+
+	invs := make([]Fp, len(fps))
+
+	var expectedFp Fp
+
+	FpBatchInv(invs, fps)
+
+	assert.True(t, invs[3].IsZero())
+
+	for i := range invs {
+		if i == 3 {
+			continue
+		}
+
+		FpInv(&expectedFp, &fps[i])
+		assert.True(t, expectedFp.IsEqual(&invs[i]), "fp %d", i)
+	}
+
+	assert.Panics(t, func() { FrBatchInv(out, x[1:]) })
+}
+
+func Test_NormalizeVec(t *testing.T) {
+	t.Parallel()
+
+	x := testGenRandomFrs(t, 6)
+
+	g1s, g2s := make([]G1, len(x)), make([]G2, len(x))
+
+	// projective points with zero and normalized ones among them
+	for i := range x {
+		G1Mul(&g1s[i], ellipticCurveG1, &x[i])
+		G1Dbl(&g1s[i], &g1s[i])
+		G2Mul(&g2s[i], ellipticCurveG2, &x[i])
+		G2Dbl(&g2s[i], &g2s[i])
+	}
+
+	g1s[1].Clear()
+	g2s[1].Clear()
+	G1Normalize(&g1s[3], &g1s[3])
+	G2Normalize(&g2s[3], &g2s[3])
+
+	out1, out2 := make([]G1, len(x)), make([]G2, len(x))
+
+	G1NormalizeVec(out1, g1s)
+	G2NormalizeVec(out2, g2s)
+
+	for i := range x {
+		var (
+			expected1 G1
+			expected2 G2
+		)
+
+		G1Normalize(&expected1, &g1s[i])
+		G2Normalize(&expected2, &g2s[i])
+
+		assert.Equal(t, G1ToBytes(&expected1), G1ToBytes(&out1[i]), "g1 %d", i)
+		assert.Equal(t, G2ToBytes(&expected2), G2ToBytes(&out2[i]), "g2 %d", i)
+
+		if i != 1 {
+			assert.True(t, out1[i].Z.IsOne(), "g1 %d", i)
+			assert.True(t, out2[i].Z.IsOne(), "g2 %d", i)
+		} else {
+			assert.True(t, out1[i].IsZero())
+			assert.True(t, out2[i].IsZero())
+		}
+	}
+
+	G1NormalizeVec(g1s, g1s)
+	G2NormalizeVec(g2s, g2s)
+
+	assert.Equal(t, out1, g1s)
+	assert.Equal(t, out2, g2s)
+}
+
+func Benchmark_FrMulVec(b *testing.B) {
+	const n = 1024
+
+	x, y := testGenRandomFrs(b, n), testGenRandomFrs(b, n)
+	out := make([]Fr, n)
+
+	b.Run("FrMul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range out {
+				FrMul(&out[j], &x[j], &y[j])
+			}
+		}
+	})
+
+	b.Run("FrMulVec", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			FrMulVec(out, x, y)
+		}
+	})
+
+	b.Run("FrInv", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range out {
+				FrInv(&out[j], &x[j])
+			}
+		}
+	})
+
+	b.Run("FrBatchInv", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			FrBatchInv(out, x)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"sync"
+	"time"
+)
+
+// VerificationCache remembers successful verifications, so a signature received several times is
+// verified once. Only positive results are cached, a failed verification is repeated every time,
+// so invalid signatures can not occupy the cache. VerificationCache is safe for concurrent use
+type VerificationCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[[32]byte]*list.Element
+	lru     *list.List
+}
+
+type verificationCacheEntry struct {
+	key     [32]byte
+	expires time.Time
+}
+
+// NewVerificationCache creates a cache holding at most size verifications. If ttl is positive,
+// cached verifications expire after ttl, otherwise they are only evicted by newer ones
+func NewVerificationCache(size int, ttl time.Duration) *VerificationCache {
+	if size < 1 {
+		size = 1
+	}
+
+	return &VerificationCache{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[[32]byte]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Verify is Signature.Verify which skips verifications cached before
+func (c *VerificationCache) Verify(signature *Signature, publicKey *PublicKey, message []byte) bool {
+	if signature.p == nil || publicKey.p == nil {
+		return false
+	}
+
+	key := verificationKey(signature, []*PublicKey{publicKey}, message)
+	if c.contains(&key) {
+		return true
+	}
+
+	if !signature.Verify(publicKey, message) {
+		return false
+	}
+
+	c.add(&key)
+
+	return true
+}
+
+// VerifyAggregated is Signature.VerifyAggregated which skips verifications cached before
+func (c *VerificationCache) VerifyAggregated(signature *Signature, publicKeys []*PublicKey, message []byte) bool {
+	if signature.p == nil {
+		return false
+	}
+
+	key := verificationKey(signature, publicKeys, message)
+	if c.contains(&key) {
+		return true
+	}
+
+	if !signature.VerifyAggregated(publicKeys, message) {
+		return false
+	}
+
+	c.add(&key)
+
+	return true
+}
+
+// Len returns the number of cached verifications including expired ones not evicted yet
+func (c *VerificationCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// contains reports whether the key is cached and not expired, expired key is removed
+func (c *VerificationCache) contains(key *[32]byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[*key]
+	if !ok {
+		return false
+	}
+
+	if c.ttl > 0 && !c.now().Before(e.Value.(*verificationCacheEntry).expires) {
+		delete(c.entries, *key)
+		c.lru.Remove(e)
+
+		return false
+	}
+
+	c.lru.MoveToFront(e)
+
+	return true
+}
+
+// add caches the key evicting the least recently used one if the cache is full
+func (c *VerificationCache) add(key *[32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+
+	if e, ok := c.entries[*key]; ok {
+		e.Value.(*verificationCacheEntry).expires = expires
+		c.lru.MoveToFront(e)
+
+		return
+	}
+
+	if c.lru.Len() < c.size {
+		c.entries[*key] = c.lru.PushFront(&verificationCacheEntry{key: *key, expires: expires})
+
+		return
+	}
+
+	e := c.lru.Back()
+	entry := e.Value.(*verificationCacheEntry)
+
+	delete(c.entries, entry.key)
+	entry.key, entry.expires = *key, expires
+	c.entries[*key] = e
+	c.lru.MoveToFront(e)
+}
+
+// verificationKey hashes the domain, the message, the signature and the public keys.
+// Variable length values are prefixed with their lengths, so different inputs can not collide
+func verificationKey(signature *Signature, publicKeys []*PublicKey, message []byte) (key [32]byte) {
+	h := sha256.New()
+
+	writeWithLength(h, GetDomain())
+	writeWithLength(h, message)
+	writeWithLength(h, G1ToBytes(signature.p))
+
+	for _, publicKey := range publicKeys {
+		writeWithLength(h, publicKey.Marshal())
+	}
+
+	h.Sum(key[:0])
+
+	return key
+}
+
+func writeWithLength(h hash.Hash, data []byte) {
+	var length [8]byte
+
+	binary.BigEndian.PutUint64(length[:], uint64(len(data)))
+
+	_, _ = h.Write(length[:])
+	_, _ = h.Write(data)
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VerificationCache(t *testing.T) {
+	t.Parallel()
+
+	validTestMsg, invalidTestMsg := testGenRandomBytes(t, messageSize), testGenRandomBytes(t, messageSize)
+
+	blsKeys, err := CreateRandomBlsKeys(2)
+	require.NoError(t, err)
+
+	publicKeys := CollectPublicKeys(blsKeys)
+	signatures := make([]*Signature, len(blsKeys))
+
+	for i, key := range blsKeys {
+		signatures[i], err = key.Sign(validTestMsg)
+		require.NoError(t, err)
+	}
+
+	aggregated := AggregateSignatures(signatures)
+	cache := NewVerificationCache(2, 0)
+
+	// failed verifications are not cached
+	assert.False(t, cache.Verify(signatures[0], publicKeys[0], invalidTestMsg))
+	assert.False(t, cache.Verify(signatures[0], publicKeys[1], validTestMsg))
+	assert.False(t, cache.VerifyAggregated(aggregated, publicKeys[:1], validTestMsg))
+	assert.False(t, cache.Verify(&Signature{}, publicKeys[0], validTestMsg))
+	assert.Zero(t, cache.Len())
+
+	for i := 0; i < 2; i++ {
+		assert.True(t, cache.Verify(signatures[0], publicKeys[0], validTestMsg))
+		assert.True(t, cache.VerifyAggregated(aggregated, publicKeys, validTestMsg))
+		assert.Equal(t, 2, cache.Len())
+	}
+
+	// the least recently used verification is evicted
+	assert.True(t, cache.Verify(signatures[1], publicKeys[1], validTestMsg))
+	assert.Equal(t, 2, cache.Len())
+	assert.Contains(t, cache.entries, verificationKey(aggregated, publicKeys, validTestMsg))
+	assert.NotContains(t, cache.entries, verificationKey(signatures[0], publicKeys[:1], validTestMsg))
+
+	// the key depends on every input
+	keys := map[[32]byte]struct{}{
+		verificationKey(signatures[0], publicKeys[:1], validTestMsg):   {},
+		verificationKey(signatures[0], publicKeys[:1], invalidTestMsg): {},
+		verificationKey(signatures[1], publicKeys[:1], validTestMsg):   {},
+		verificationKey(signatures[0], publicKeys[1:], validTestMsg):   {},
+		verificationKey(signatures[0], publicKeys, validTestMsg):       {},
+	}
+
+	assert.Len(t, keys, 5)
+}
+
+func Test_VerificationCacheTTL(t *testing.T) {
+	t.Parallel()
+
+	validTestMsg := testGenRandomBytes(t, messageSize)
+
+	blsKey, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	signature, err := blsKey.Sign(validTestMsg)
+	require.NoError(t, err)
+
+	now := time.Unix(1000, 0)
+	cache := NewVerificationCache(4, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	key := verificationKey(signature, []*PublicKey{blsKey.PublicKey()}, validTestMsg)
+
+	assert.True(t, cache.Verify(signature, blsKey.PublicKey(), validTestMsg))
+	assert.True(t, cache.contains(&key))
+
+	now = now.Add(time.Minute - time.Second)
+	assert.True(t, cache.contains(&key))
+
+	// expired verification is removed
+	now = now.Add(time.Second)
+	assert.False(t, cache.contains(&key))
+	assert.Zero(t, cache.Len())
+
+	assert.True(t, cache.Verify(signature, blsKey.PublicKey(), validTestMsg))
+	assert.Equal(t, 1, cache.Len())
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"crypto/sha256"
+	"sync"
+)
+
+// Verifier is a reusable context of signature verification. With the default HashToG1 verification
+// does not allocate. Verifier is not safe for concurrent use, use one Verifier per goroutine
+type Verifier struct {
+	hash         hashScratch
+	messagePoint G1
+	publicKey    G2
+	e1, e2       GT
+	fused        bool
+}
+
+var verifierPool = sync.Pool{
+	New: func() interface{} {
+		return NewVerifier()
+	},
+}
+
+// NewVerifier creates a new verification context
+func NewVerifier() *Verifier {
+	return &Verifier{hash: hashScratch{h: sha256.New()}}
+}
+
+// NewFusedVerifier creates a verification context which checks the pairing equation in a single backend
+// call, see Benchmark_Verify. With the default HashToG1 and no hash cache mapping of the message runs
+// in the same call
+func NewFusedVerifier() *Verifier {
+	v := NewVerifier()
+	v.fused = true
+
+	return v
+}
+
+// Verify checks the BLS signature of the message against the public key of its signer
+func (v *Verifier) Verify(signature *Signature, publicKey *PublicKey, message []byte) bool {
+	return v.verify(signature, publicKey.p, message)
+}
+
+// VerifyAggregated checks the BLS signature of the message against the aggregated public keys of its signers
+func (v *Verifier) VerifyAggregated(signature *Signature, publicKeys []*PublicKey, message []byte) bool {
+	aggregatePublicKeysInto(&v.publicKey, publicKeys)
+
+	return v.verify(signature, &v.publicKey, message)
+}
+
+// verify checks e(signature, G2) * e(-H(message), publicKey) == 1
+func (v *Verifier) verify(signature *Signature, publicKey *G2, message []byte) bool {
+	if v.fused && customHashToG1 == nil && !hashCache.enabled() {
+		if err := v.hash.expandMsgSHA256XMD(v.hash.uniform[:], message, GetDomain()); err != nil {
+			return false
+		}
+
+		return blsVerify(signature.p, publicKey, nil, v.hash.uniform[:])
+	}
+
+	if err := hashToG1Into(&v.messagePoint, message, &v.hash); err != nil {
+		return false
+	}
+
+	if v.fused {
+		return blsVerify(signature.p, publicKey, &v.messagePoint, nil)
+	}
+
+	return v.verifyMessagePoint(signature.p, publicKey)
+}
+
+// verifyMessagePoint checks e(signature, G2) * e(-messagePoint, publicKey) == 1, messagePoint is overwritten
+func (v *Verifier) verifyMessagePoint(signature *G1, publicKey *G2) bool {
+	G1Neg(&v.messagePoint, &v.messagePoint)
+	PrecomputedMillerLoop(&v.e1, signature, GetCoef())
+	MillerLoop(&v.e2, &v.messagePoint, publicKey)
+	GTMul(&v.e1, &v.e1, &v.e2)
+	FinalExp(&v.e1, &v.e1)
+
+	return v.e1.IsOne()
+}
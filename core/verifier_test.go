@@ -0,0 +1,217 @@
+package core
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Verifier(t *testing.T) {
+	t.Parallel()
+
+	blsKeys, err := CreateRandomBlsKeys(3)
+	require.NoError(t, err)
+
+	publicKeys := CollectPublicKeys(blsKeys)
+
+	verifiers := []*Verifier{NewVerifier(), NewFusedVerifier()}
+
+	for i := 0; i < 3; i++ {
+		validTestMsg, invalidTestMsg := testGenRandomBytes(t, messageSize), testGenRandomBytes(t, messageSize)
+
+		signatures := make([]*Signature, len(blsKeys))
+
+		for j, key := range blsKeys {
+			signatures[j], err = key.Sign(validTestMsg)
+			require.NoError(t, err)
+		}
+
+		aggregated := AggregateSignatures(signatures)
+
+		for _, verifier := range verifiers {
+			assert.True(t, verifier.Verify(signatures[0], publicKeys[0], validTestMsg))
+			assert.False(t, verifier.Verify(signatures[0], publicKeys[0], invalidTestMsg))
+			assert.False(t, verifier.Verify(signatures[0], publicKeys[1], validTestMsg))
+
+			assert.True(t, verifier.VerifyAggregated(aggregated, publicKeys, validTestMsg))
+			assert.False(t, verifier.VerifyAggregated(aggregated, publicKeys, invalidTestMsg))
+			assert.False(t, verifier.VerifyAggregated(aggregated, publicKeys[1:], validTestMsg))
+		}
+	}
+}
+
+func Test_AggregateInto(t *testing.T) {
+	t.Parallel()
+
+	validTestMsg := testGenRandomBytes(t, messageSize)
+
+	blsKeys, err := CreateRandomBlsKeys(4)
+	require.NoError(t, err)
+
+	publicKeys := CollectPublicKeys(blsKeys)
+	signatures := make([]*Signature, len(blsKeys))
+
+	for i, key := range blsKeys {
+		signatures[i], err = key.Sign(validTestMsg)
+		require.NoError(t, err)
+	}
+
+	expectedSignature := AggregateSignatures(signatures)
+	expectedPublicKey := AggregatePublicKeys(publicKeys)
+
+	// accumulate starting from the empty values
+	signature, publicKey := &Signature{}, &PublicKey{}
+
+	for i := range signatures {
+		signature.AggregateInto(signature, signatures[i])
+		publicKey.AggregateInto(publicKey, publicKeys[i])
+	}
+
+	assert.True(t, expectedSignature.p.IsEqual(signature.p))
+	assert.True(t, expectedPublicKey.p.IsEqual(publicKey.p))
+
+	// dst is reused and overwritten
+	assert.Same(t, signature, AggregateSignaturesInto(signature, signatures[:2]))
+	assert.Same(t, publicKey, AggregatePublicKeysInto(publicKey, publicKeys[:2]))
+	assert.True(t, signatures[0].Aggregate(signatures[1]).p.IsEqual(signature.p))
+	assert.True(t, publicKeys[0].Aggregate(publicKeys[1]).p.IsEqual(publicKey.p))
+
+	// empty values are the identity
+	assert.True(t, (&Signature{}).AggregateInto(signature, &Signature{}).p.IsZero())
+	assert.True(t, (&PublicKey{}).AggregateInto(publicKey, &PublicKey{}).p.IsZero())
+	assert.True(t, (&Signature{}).AggregateInto(signature, signatures[2]).p.IsEqual(signatures[2].p))
+	assert.True(t, publicKeys[2].AggregateInto(publicKey, &PublicKey{}).p.IsEqual(publicKeys[2].p))
+
+	assert.True(t, AggregateSignaturesInto(signature, nil).p.IsZero())
+}
+
+func Test_BlsVerify(t *testing.T) {
+	t.Parallel()
+
+	validTestMsg, invalidTestMsg := testGenRandomBytes(t, messageSize), testGenRandomBytes(t, messageSize)
+
+	blsKey, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	signature, err := blsKey.Sign(validTestMsg)
+	require.NoError(t, err)
+
+	publicKey := blsKey.PublicKey()
+	s := &hashScratch{h: sha256.New()}
+
+	for _, c := range []struct {
+		message []byte
+		valid   bool
+	}{
+		{validTestMsg, true},
+		{invalidTestMsg, false},
+	} {
+		messagePoint, err := HashToG107(c.message)
+		require.NoError(t, err)
+
+		require.NoError(t, s.expandMsgSHA256XMD(s.uniform[:], c.message, GetDomain()))
+
+		assert.Equal(t, c.valid, testVerifySeparate(signature.p, publicKey.p, messagePoint))
+		assert.Equal(t, c.valid, blsVerify(signature.p, publicKey.p, messagePoint, nil))
+		assert.Equal(t, c.valid, blsVerify(signature.p, publicKey.p, nil, s.uniform[:]))
+	}
+}
+
+// testVerifySeparate is the verification with a backend call per step
+func testVerifySeparate(signature *G1, publicKey *G2, messagePoint *G1) bool {
+	var (
+		p      G1
+		e1, e2 GT
+	)
+
+	G1Neg(&p, messagePoint)
+	PrecomputedMillerLoop(&e1, signature, GetCoef())
+	MillerLoop(&e2, &p, publicKey)
+	GTMul(&e1, &e1, &e2)
+	FinalExp(&e1, &e1)
+
+	return e1.IsOne()
+}
+
+// Test_VerifierAllocations must not run in parallel as testing.AllocsPerRun does not allow it
+func Test_VerifierAllocations(t *testing.T) {
+	if testBackendName == "purego" {
+		t.Skip("the pure Go backend allocates in the pairing")
+	}
+
+	message := testGenRandomBytes(t, messageSize)
+
+	blsKeys, err := CreateRandomBlsKeys(2)
+	require.NoError(t, err)
+
+	publicKeys := CollectPublicKeys(blsKeys)
+	signatures := make([]*Signature, len(blsKeys))
+
+	for i, key := range blsKeys {
+		signatures[i], err = key.Sign(message)
+		require.NoError(t, err)
+	}
+
+	verifier := NewVerifier()
+	aggregated := AggregateSignatures(signatures)
+
+	assert.Zero(t, testing.AllocsPerRun(10, func() {
+		verifier.Verify(signatures[0], publicKeys[0], message)
+	}))
+
+	assert.Zero(t, testing.AllocsPerRun(10, func() {
+		verifier.VerifyAggregated(aggregated, publicKeys, message)
+	}))
+
+	fused := NewFusedVerifier()
+
+	assert.Zero(t, testing.AllocsPerRun(10, func() {
+		fused.Verify(signatures[0], publicKeys[0], message)
+	}))
+
+	assert.Zero(t, testing.AllocsPerRun(10, func() {
+		AggregateSignaturesInto(aggregated, signatures)
+	}))
+}
+
+func Benchmark_Verify(b *testing.B) {
+	message := testGenRandomBytes(b, messageSize)
+
+	blsKey, err := GenerateBlsKey()
+	require.NoError(b, err)
+
+	signature, err := blsKey.Sign(message)
+	require.NoError(b, err)
+
+	publicKey := blsKey.PublicKey()
+
+	b.Run("Signature", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			signature.Verify(publicKey, message)
+		}
+	})
+
+	b.Run("Verifier", func(b *testing.B) {
+		verifier := NewVerifier()
+
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			verifier.Verify(signature, publicKey, message)
+		}
+	})
+
+	b.Run("FusedVerifier", func(b *testing.B) {
+		verifier := NewFusedVerifier()
+
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			verifier.Verify(signature, publicKey, message)
+		}
+	})
+}
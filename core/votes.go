@@ -0,0 +1,99 @@
+package core
+
+import "sort"
+
+// Vote is a signature of the message by the owner of the public key
+type Vote struct {
+	Signature *Signature
+	PublicKey *PublicKey
+	Message   []byte
+}
+
+// VerifyVotes checks the votes and returns indices of invalid ones, nil means all votes are valid.
+// Votes are grouped by message, signatures and public keys of each group are aggregated and all
+// groups are checked with one multi-pairing. Groups are weighted with random scalars, so an invalid
+// group can not be compensated by another one. Like VerifyAggregated, a group passes if its aggregate
+// is valid. If the multi-pairing fails, every vote is verified separately to find the invalid ones
+func VerifyVotes(votes []Vote) []int {
+	groups, order := make(map[string][]int), make([]string, 0)
+
+	var invalid []int
+
+	for i, vote := range votes {
+		if vote.Signature == nil || vote.Signature.p == nil || vote.PublicKey == nil || vote.PublicKey.p == nil {
+			invalid = append(invalid, i)
+
+			continue
+		}
+
+		key := string(vote.Message)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+
+		groups[key] = append(groups[key], i)
+	}
+
+	if len(order) == 0 || verifyVoteGroups(votes, groups, order) {
+		return invalid
+	}
+
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+
+	for _, key := range order {
+		for _, i := range groups[key] {
+			if !v.Verify(votes[i].Signature, votes[i].PublicKey, votes[i].Message) {
+				invalid = append(invalid, i)
+			}
+		}
+	}
+
+	sort.Ints(invalid)
+
+	return invalid
+}
+
+// verifyVoteGroups checks e(sum r_j S_j, G2) == prod e(H(m_j), r_j P_j), where S_j and P_j are
+// aggregated signatures and public keys of the group j. A single group needs no weight
+func verifyVoteGroups(votes []Vote, groups map[string][]int, order []string) bool {
+	var (
+		r         Fr
+		signature G1
+		sum       G1
+	)
+
+	g1s, g2s := make([]G1, len(order)+1), make([]G2, len(order)+1)
+
+	s := hashScratchPool.Get().(*hashScratch)
+	defer hashScratchPool.Put(s)
+
+	for j, key := range order {
+		signature.Clear()
+
+		for _, i := range groups[key] {
+			G1Add(&signature, &signature, votes[i].Signature.p)
+			G2Add(&g2s[j], &g2s[j], votes[i].PublicKey.p)
+		}
+
+		if len(order) > 1 {
+			if !r.SetByCSPRNG() {
+				return false
+			}
+
+			G1Mul(&signature, &signature, &r)
+			G2Mul(&g2s[j], &g2s[j], &r)
+		}
+
+		G1Add(&sum, &sum, &signature)
+
+		if err := hashToG1Into(&g1s[j], []byte(key), s); err != nil {
+			return false
+		}
+	}
+
+	G1Neg(&g1s[len(order)], &sum)
+	g2s[len(order)] = *ellipticCurveG2
+
+	return PairingCheck(g1s, g2s)
+}
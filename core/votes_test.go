@@ -0,0 +1,90 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGenVotes(t testing.TB, keys, messages int) []Vote {
+	t.Helper()
+
+	blsKeys, err := CreateRandomBlsKeys(keys)
+	require.NoError(t, err)
+
+	msgs := make([][]byte, messages)
+	for i := range msgs {
+		msgs[i] = testGenRandomBytes(t, messageSize)
+	}
+
+	votes := make([]Vote, len(blsKeys))
+
+	for i, key := range blsKeys {
+		votes[i].PublicKey, votes[i].Message = key.PublicKey(), msgs[i%messages]
+
+		votes[i].Signature, err = key.Sign(votes[i].Message)
+		require.NoError(t, err)
+	}
+
+	return votes
+}
+
+func Test_VerifyVotes(t *testing.T) {
+	t.Parallel()
+
+	votes := testGenVotes(t, 8, 3)
+
+	assert.Nil(t, VerifyVotes(votes))
+	assert.Nil(t, VerifyVotes(votes[:1]))
+	assert.Nil(t, VerifyVotes(nil))
+
+	// signature of another message
+	invalidVotes := append([]Vote{}, votes...)
+	invalidVotes[4].Signature = votes[5].Signature
+
+	assert.Equal(t, []int{4}, VerifyVotes(invalidVotes))
+
+	// empty values
+	invalidVotes = append([]Vote{}, votes...)
+	invalidVotes[1].Signature, invalidVotes[6].PublicKey = nil, &PublicKey{}
+
+	assert.Equal(t, []int{1, 6}, VerifyVotes(invalidVotes))
+
+	// the same difference added to one group and subtracted from another one keeps the sum of signatures
+	var (
+		x Fr
+		d G1
+	)
+
+	require.True(t, x.SetByCSPRNG())
+	G1MulGenerator(&d, &x)
+
+	p, q := new(G1), new(G1)
+
+	G1Add(p, votes[0].Signature.p, &d)
+	G1Sub(q, votes[1].Signature.p, &d)
+
+	invalidVotes = append([]Vote{}, votes...)
+	invalidVotes[0].Signature, invalidVotes[1].Signature = newSignature(p), newSignature(q)
+
+	assert.Equal(t, []int{0, 1}, VerifyVotes(invalidVotes))
+}
+
+func Benchmark_VerifyVotes(b *testing.B) {
+	votes := testGenVotes(b, 64, 2)
+
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, vote := range votes {
+				vote.Signature.Verify(vote.PublicKey, vote.Message)
+			}
+		}
+	})
+
+	b.Run("VerifyVotes", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			VerifyVotes(votes)
+		}
+	})
+}